@@ -0,0 +1,2 @@
+// Package jsonobject reads the members of JSON objects in document order, which encoding/json does not preserve
+package jsonobject
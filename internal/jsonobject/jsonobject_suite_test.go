@@ -0,0 +1,12 @@
+package jsonobject
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestJsonobject(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Jsonobject Suite")
+}
@@ -0,0 +1,84 @@
+package jsonobject
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Member is a single member of a JSON object
+type Member struct {
+	Name  string
+	Value json.RawMessage
+}
+
+// Members returns the members of the JSON object in data, in document order, including repeated names. As with
+// encoding/json, null has no members.
+func Members(data []byte) ([]Member, error) {
+
+	// report malformed JSON, and JSON that is not an object, exactly as encoding/json does
+	if err := json.Unmarshal(data, &map[string]json.RawMessage{}); err != nil {
+		return nil, err
+	}
+
+	var (
+		result  []Member
+		decoder = json.NewDecoder(bytes.NewReader(data))
+	)
+
+	// the opening brace, or null
+	if token, err := decoder.Token(); err != nil || token == nil {
+		return nil, err
+	}
+
+	for decoder.More() {
+
+		token, err := decoder.Token()
+
+		if err != nil {
+			return nil, err
+		}
+
+		var value json.RawMessage
+
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		result = append(result, Member{Name: token.(string), Value: value})
+
+	}
+
+	return result, nil
+
+}
+
+// Unique returns members with a single member per name, at the position of its first occurrence and with the value of
+// its last, which is how encoding/json decodes repeated names
+func Unique(members []Member) []Member {
+
+	var (
+		result []Member
+		index  = map[string]int{}
+	)
+
+	for _, m := range members {
+
+		if x, ok := index[m.Name]; ok {
+			result[x].Value = m.Value
+			continue
+		}
+
+		index[m.Name] = len(result)
+		result = append(result, m)
+
+	}
+
+	return result
+
+}
+
+// IsObject returns true if data, ignoring surrounding whitespace, begins a JSON object rather than another value such
+// as null
+func IsObject(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
@@ -0,0 +1,83 @@
+package jsonobject
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Members", func() {
+
+	It("Members(data) should return every member in document order", func() {
+
+		// when
+		result, err := Members([]byte(`{"b": 1, "a": [2], "b": {"c": 3}}`))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal([]Member{
+			{Name: "b", Value: json.RawMessage(`1`)},
+			{Name: "a", Value: json.RawMessage(`[2]`)},
+			{Name: "b", Value: json.RawMessage(`{"c": 3}`)},
+		}))
+
+	})
+
+	It("Members(null) should return no members", func() {
+
+		// when
+		result, err := Members([]byte(`null`))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeEmpty())
+
+	})
+
+	DescribeTable(
+		"Members(data) should return the error of encoding/json",
+		func(in string) {
+
+			// when
+			_, err := Members([]byte(in))
+
+			// then
+			Expect(err).To(Equal(json.Unmarshal([]byte(in), &map[string]json.RawMessage{})))
+
+		},
+		Entry("malformed", `{"a": `),
+		Entry("not an object", `[]`),
+	)
+
+	It("Unique(members) should keep the position of the first and the value of the last of repeated names", func() {
+
+		// given
+		in := []Member{
+			{Name: "b", Value: json.RawMessage(`1`)},
+			{Name: "a", Value: json.RawMessage(`2`)},
+			{Name: "b", Value: json.RawMessage(`3`)},
+		}
+
+		// when
+		result := Unique(in)
+
+		// then
+		Expect(result).To(Equal([]Member{
+			{Name: "b", Value: json.RawMessage(`3`)},
+			{Name: "a", Value: json.RawMessage(`2`)},
+		}))
+
+	})
+
+	DescribeTable(
+		"IsObject(data)",
+		func(in string, out bool) {
+			Expect(IsObject([]byte(in))).To(Equal(out))
+		},
+		Entry("object", ` {"a": 1}`, true),
+		Entry("null", `null`, false),
+		Entry("array", `[]`, false),
+	)
+
+})
@@ -0,0 +1,2 @@
+// Package rfc6901 contains an implementation of IETF's RFC 6901. See: https://tools.ietf.org/html/rfc6901
+package rfc6901
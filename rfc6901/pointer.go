@@ -0,0 +1,178 @@
+package rfc6901
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Pointer Errors
+var (
+	ErrInvalidPointer = errors.New("rfc6901: invalid pointer")
+	ErrInvalidEscape  = errors.New("rfc6901: invalid pointer, '~' must be followed by '0' or '1'")
+	ErrNotFound       = errors.New("rfc6901: pointer does not reference a value in the document")
+)
+
+var (
+	escaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	unescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// ParsePointer parses the string representation of a JSON Pointer as defined in RFC 6901 Sec. 3
+func ParsePointer(pointer string) (Pointer, error) {
+
+	if pointer == "" {
+		return Pointer{}, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, ErrInvalidPointer
+	}
+
+	var result Pointer
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+
+		if !validEscapes(token) {
+			return nil, ErrInvalidEscape
+		}
+
+		result = append(result, unescaper.Replace(token))
+
+	}
+
+	return result, nil
+
+}
+
+// validEscapes returns whether every '~' in the token is followed by '0' or '1'
+func validEscapes(token string) bool {
+
+	for x := 0; x < len(token); x++ {
+
+		if token[x] == '~' && (x+1 == len(token) || (token[x+1] != '0' && token[x+1] != '1')) {
+			return false
+		}
+
+	}
+
+	return true
+
+}
+
+// Pointer is a JSON Pointer as defined by RFC 6901, held as its unescaped reference tokens. The zero-value Pointer
+// references the whole document.
+type Pointer []string
+
+// NewPointer returns a Pointer made of the given unescaped reference tokens
+func NewPointer(tokens ...string) Pointer {
+	return append(Pointer{}, tokens...)
+}
+
+// Append returns a new Pointer with the given unescaped reference tokens appended
+func (p Pointer) Append(tokens ...string) Pointer {
+	return append(append(Pointer{}, p...), tokens...)
+}
+
+// AppendIndex returns a new Pointer with the given array index appended
+func (p Pointer) AppendIndex(index int) Pointer {
+	return p.Append(strconv.Itoa(index))
+}
+
+// String returns the Pointer in its string representation, escaping '~' as "~0" and '/' as "~1" as defined by
+// RFC 6901 Sec. 3
+func (p Pointer) String() string {
+
+	var b strings.Builder
+
+	for _, token := range p {
+		b.WriteString("/")
+		b.WriteString(escaper.Replace(token))
+	}
+
+	return b.String()
+
+}
+
+// Evaluate returns the value referenced by the Pointer within a document as decoded by encoding/json into an
+// interface{}, as defined by RFC 6901 Sec. 4
+func (p Pointer) Evaluate(document interface{}) (interface{}, error) {
+
+	value := document
+
+	for _, token := range p {
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+
+			member, ok := v[token]
+
+			if !ok {
+				return nil, ErrNotFound
+			}
+
+			value = member
+
+		case []interface{}:
+
+			index, ok := arrayIndex(token)
+
+			if !ok || index >= len(v) {
+				return nil, ErrNotFound
+			}
+
+			value = v[index]
+
+		default:
+			return nil, ErrNotFound
+		}
+
+	}
+
+	return value, nil
+
+}
+
+// arrayIndex parses an array index as defined by RFC 6901 Sec. 4: digits without leading zeros
+func arrayIndex(token string) (int, bool) {
+
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+
+	for _, r := range token {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+
+	index, err := strconv.Atoi(token)
+	return index, err == nil
+
+}
+
+// MarshalJSON Marshals JSON as a JSON string as described by RFC 6901 Sec. 5
+func (p Pointer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON unmarshalls JSON from a JSON string
+func (p *Pointer) UnmarshalJSON(data []byte) error {
+
+	var str string
+
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	pointer, err := ParsePointer(str)
+
+	if err != nil {
+		return err
+	}
+
+	*p = pointer
+	return nil
+
+}
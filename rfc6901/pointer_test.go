@@ -0,0 +1,146 @@
+package rfc6901
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pointer", func() {
+
+	// the example document from RFC 6901 Sec. 5
+	var document interface{}
+	json.Unmarshal([]byte(`{
+        "foo": ["bar", "baz"],
+        "": 0,
+        "a/b": 1,
+        "c%d": 2,
+        "e^f": 3,
+        "g|h": 4,
+        "i\\j": 5,
+        "k\"l": 6,
+        " ": 7,
+        "m~n": 8
+    }`), &document)
+
+	DescribeTable(
+		"ParsePointer() and Evaluate()",
+		func(in string, tokens Pointer, value interface{}) {
+
+			// when
+			result, err := ParsePointer(in)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(tokens))
+			Expect(result.String()).To(Equal(in))
+
+			evaluated, err := result.Evaluate(document)
+			Expect(err).To(BeNil())
+			Expect(evaluated).To(Equal(value))
+
+		},
+		Entry("whole document", "", Pointer{}, document),
+		Entry("member", "/foo", Pointer{"foo"}, []interface{}{"bar", "baz"}),
+		Entry("array index", "/foo/0", Pointer{"foo", "0"}, "bar"),
+		Entry("empty member name", "/", Pointer{""}, float64(0)),
+		Entry("escaped '/'", "/a~1b", Pointer{"a/b"}, float64(1)),
+		Entry("percent", "/c%d", Pointer{"c%d"}, float64(2)),
+		Entry("caret", "/e^f", Pointer{"e^f"}, float64(3)),
+		Entry("pipe", "/g|h", Pointer{"g|h"}, float64(4)),
+		Entry("backslash", `/i\j`, Pointer{`i\j`}, float64(5)),
+		Entry("quote", `/k"l`, Pointer{`k"l`}, float64(6)),
+		Entry("space", "/ ", Pointer{" "}, float64(7)),
+		Entry("escaped '~'", "/m~0n", Pointer{"m~n"}, float64(8)),
+	)
+
+	DescribeTable(
+		"String() escaping",
+		func(in Pointer, out string) {
+			Expect(in.String()).To(Equal(out))
+		},
+		Entry("'~' is escaped as ~0", NewPointer("m~n"), "/m~0n"),
+		Entry("'/' is escaped as ~1", NewPointer("a/b"), "/a~1b"),
+		Entry("'~1' is not double unescaped", NewPointer("~1"), "/~01"),
+		Entry("nested", NewPointer("items").AppendIndex(3).Append("name"), "/items/3/name"),
+	)
+
+	It("should unescape ~01 as ~1, not /", func() {
+
+		// when
+		result, err := ParsePointer("/~01")
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(Pointer{"~1"}))
+
+	})
+
+	DescribeTable(
+		"ParsePointer() error cases",
+		func(in string, out error) {
+
+			// when
+			_, err := ParsePointer(in)
+
+			// then
+			Expect(err).To(Equal(out))
+
+		},
+		Entry("missing leading '/'", "foo", ErrInvalidPointer),
+		Entry("'~' at end", "/foo~", ErrInvalidEscape),
+		Entry("'~' followed by 2", "/foo~2", ErrInvalidEscape),
+	)
+
+	DescribeTable(
+		"Evaluate() error cases",
+		func(in Pointer) {
+
+			// when
+			_, err := in.Evaluate(document)
+
+			// then
+			Expect(err).To(Equal(ErrNotFound))
+
+		},
+		Entry("missing member", Pointer{"missing"}),
+		Entry("index out of range", Pointer{"foo", "2"}),
+		Entry("index with leading zero", Pointer{"foo", "01"}),
+		Entry("past the end", Pointer{"foo", "-"}),
+		Entry("through a scalar", Pointer{"a/b", "c"}),
+	)
+
+	It("should marshal and unmarshal JSON as a string", func() {
+
+		// given
+		p := NewPointer("a/b", "m~n")
+
+		// when
+		data, err := json.Marshal(p)
+		result := Pointer{}
+		json.Unmarshal(data, &result)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(Equal(`"/a~1b/m~0n"`))
+		Expect(result).To(Equal(p))
+
+	})
+
+	It("should not share storage between appended Pointers", func() {
+
+		// given
+		base := NewPointer("items")
+
+		// when
+		first := base.Append("a")
+		second := base.Append("b")
+
+		// then
+		Expect(first.String()).To(Equal("/items/a"))
+		Expect(second.String()).To(Equal("/items/b"))
+
+	})
+
+})
@@ -0,0 +1,12 @@
+package rfc6901
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestRfc6901(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Rfc6901 Suite")
+}
@@ -0,0 +1,154 @@
+package rfc7231
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseAcceptLanguage parses the value of an HTTP Accept-Language Header as defined in RFC 7231 Sec. 5.3.5
+func ParseAcceptLanguage(acceptLanguage string) (AcceptLanguage, error) {
+
+	var (
+		rs io.RuneScanner = strings.NewReader(acceptLanguage)
+		s                 = scanner{runeScanner: rs}
+		p                 = parser{scanner: s}
+	)
+
+	languageRanges, err := p.parseLanguageRanges()
+
+	if err != nil {
+		return AcceptLanguage{}, err
+	}
+
+	return AcceptLanguage{languageRanges: languageRanges}, nil
+
+}
+
+// AcceptLanguage represents the value of an HTTP Accept-Language Header as defined in RFC 7231 Sec. 5.3.5
+type AcceptLanguage struct {
+	languageRanges []languageRange
+}
+
+// MostAcceptable returns the single most acceptable language tag by quality, matching language ranges to tags by the
+// "Basic Filtering" scheme of RFC 4647 Sec. 3.3.1. Ties are broken by the order of the given tags. The zero-value
+// AcceptLanguage accepts any language, preferring the first tag. If no given tag is acceptable, will return "", false
+func (a AcceptLanguage) MostAcceptable(tags []string) (string, bool) {
+
+	var (
+		result string
+		best   float64
+	)
+
+	for _, tag := range tags {
+
+		if q := a.quality(tag); q > best {
+			result, best = tag, q
+		}
+
+	}
+
+	return result, best > 0
+
+}
+
+// Acceptable returns whether or not the language tag is acceptable to the HTTP Accept-Language Header
+func (a AcceptLanguage) Acceptable(tag string) bool {
+	return a.quality(tag) > 0
+}
+
+// Excludes returns whether or not the language tag is explicitly rejected by the HTTP Accept-Language Header, i.e. the
+// most specific language range matching it has a quality of 0. A tag matched by no language range is not excluded.
+func (a AcceptLanguage) Excludes(tag string) bool {
+
+	q, matched := a.match(tag)
+	return matched && q == 0
+
+}
+
+// quality returns the quality of the most specific language range matching the tag, or 0 if none match
+func (a AcceptLanguage) quality(tag string) float64 {
+
+	q, _ := a.match(tag)
+	return q
+
+}
+
+// match returns the quality of the most specific language range matching the tag, and whether any matched
+func (a AcceptLanguage) match(tag string) (float64, bool) {
+
+	// treat zero-value AcceptLanguage{} as "*"
+	if len(a.languageRanges) == 0 {
+		return 1, true
+	}
+
+	var (
+		q           float64
+		specificity = -1
+	)
+
+	for _, lr := range a.languageRanges {
+
+		if s := lr.matches(tag); s > specificity {
+			q, specificity = lr.Q, s
+		}
+
+	}
+
+	return q, specificity >= 0
+
+}
+
+// String the string representation of the HTTP Accept-Language Header
+func (a AcceptLanguage) String() string {
+
+	// treat zero-value AcceptLanguage{} as "*"
+	if len(a.languageRanges) == 0 {
+		return "*"
+	}
+
+	var lrStrings []string
+
+	for _, lr := range a.languageRanges {
+		lrStrings = append(lrStrings, lr.String())
+	}
+
+	return strings.Join(lrStrings, ", ")
+
+}
+
+// languageRange represents a language-range with its quality for use in an HTTP Accept-Language Header
+type languageRange struct {
+	Tag string
+	Q   float64
+}
+
+// String returns the string representation of the languageRange
+func (l languageRange) String() string {
+
+	if l.Q == 1 {
+		return l.Tag
+	}
+
+	return l.Tag + ";q=" + strconv.FormatFloat(l.Q, 'f', -1, 64)
+
+}
+
+// matches returns the specificity with which the languageRange matches the tag, or -1 if it does not. A range
+// matches a tag if it equals the tag, or is a prefix of the tag such that the first character following the prefix
+// is "-". "*" matches any tag with the lowest specificity.
+func (l languageRange) matches(tag string) int {
+
+	tag = strings.ToLower(tag)
+
+	if l.Tag == "*" {
+		return 0
+	}
+
+	if tag == l.Tag || strings.HasPrefix(tag, l.Tag+"-") {
+		return len(l.Tag)
+	}
+
+	return -1
+
+}
@@ -0,0 +1,170 @@
+package rfc7231
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AcceptLanguage", func() {
+
+	type parseAcceptLanguageExample struct {
+		in  string
+		out AcceptLanguage
+	}
+
+	DescribeTable("ParseAcceptLanguage()",
+		func(example parseAcceptLanguageExample) {
+
+			// when
+			result, err := ParseAcceptLanguage(example.in)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(example.out))
+
+		},
+		Entry("example 1", parseAcceptLanguageExample{
+			in: "da, en-GB;q=0.8, en;q=0.7",
+			out: AcceptLanguage{
+				languageRanges: []languageRange{
+					{Tag: "da", Q: 1},
+					{Tag: "en-gb", Q: 0.8},
+					{Tag: "en", Q: 0.7},
+				},
+			},
+		}),
+		Entry("wildcard and q=0", parseAcceptLanguageExample{
+			in: "*, fr;q=0",
+			out: AcceptLanguage{
+				languageRanges: []languageRange{
+					{Tag: "*", Q: 1},
+					{Tag: "fr", Q: 0},
+				},
+			},
+		}),
+		Entry("empty", parseAcceptLanguageExample{
+			in:  "",
+			out: AcceptLanguage{},
+		}),
+	)
+
+	DescribeTable("ParseAcceptLanguage() error cases",
+		func(in string, out error) {
+
+			// when
+			_, err := ParseAcceptLanguage(in)
+
+			// then
+			Expect(err).To(Equal(out))
+
+		},
+		Entry("digit in primary subtag", "e1", ErrInvalidLanguageRange),
+		Entry("subtag too long", "en-abcdefghi", ErrInvalidLanguageRange),
+		Entry("media range", "text/html", ErrInvalidLanguageRange),
+		Entry("q out of range", "en;q=2", ErrQMustBeNumberBetween0And1),
+	)
+
+	type mostAcceptableExample struct {
+		header string
+		tags   []string
+
+		result string
+		ok     bool
+	}
+
+	DescribeTable("MostAcceptable(tags)",
+		func(e mostAcceptableExample) {
+
+			// given
+			acceptLanguage, err := ParseAcceptLanguage(e.header)
+			Expect(err).To(BeNil())
+
+			// when
+			result, ok := acceptLanguage.MostAcceptable(e.tags)
+
+			// then
+			Expect(ok).To(Equal(e.ok))
+			Expect(result).To(Equal(e.result))
+
+		},
+		Entry("Example 1", mostAcceptableExample{
+			header: "da, en-GB;q=0.8, en;q=0.7",
+			tags:   []string{"en", "en-GB", "da"},
+			result: "da",
+			ok:     true,
+		}),
+		Entry("Example 2: the most specific range wins", mostAcceptableExample{
+			header: "da, en-GB;q=0.8, en;q=0.7",
+			tags:   []string{"en-US", "en-GB"},
+			result: "en-GB",
+			ok:     true,
+		}),
+		Entry("Example 3: prefix match", mostAcceptableExample{
+			header: "de",
+			tags:   []string{"en", "de-CH"},
+			result: "de-CH",
+			ok:     true,
+		}),
+		Entry("Example 4: q=0 excludes a tag matched by *", mostAcceptableExample{
+			header: "*, fr;q=0",
+			tags:   []string{"fr", "it"},
+			result: "it",
+			ok:     true,
+		}),
+		Entry("Example 5: none acceptable", mostAcceptableExample{
+			header: "de, fr",
+			tags:   []string{"en"},
+			result: "",
+			ok:     false,
+		}),
+		Entry("Example 6: no header accepts the first tag", mostAcceptableExample{
+			header: "",
+			tags:   []string{"en", "de"},
+			result: "en",
+			ok:     true,
+		}),
+		Entry("Example 7: a range is not a partial subtag prefix", mostAcceptableExample{
+			header: "en",
+			tags:   []string{"eng"},
+			result: "",
+			ok:     false,
+		}),
+	)
+
+	DescribeTable("Excludes(tag)",
+		func(header string, tag string, excluded bool) {
+
+			// given
+			acceptLanguage, err := ParseAcceptLanguage(header)
+			Expect(err).To(BeNil())
+
+			// expect
+			Expect(acceptLanguage.Excludes(tag)).To(Equal(excluded))
+
+		},
+		Entry("q=0", "en;q=0, fr", "en", true),
+		Entry("q=0 by prefix", "en;q=0, fr", "en-GB", true),
+		Entry("more specific range", "en;q=0, en-GB", "en-GB", false),
+		Entry("wildcard q=0", "fr, *;q=0", "en", true),
+		Entry("not matched", "fr", "en", false),
+		Entry("acceptable", "en;q=0.1", "en", false),
+		Entry("no header", "", "en", false),
+	)
+
+	Describe("String()", func() {
+
+		It("should omit q=1", func() {
+
+			// given
+			acceptLanguage, _ := ParseAcceptLanguage("da, en-GB;q=0.8")
+
+			// expect
+			Expect(acceptLanguage.String()).To(Equal("da, en-gb;q=0.8"))
+			Expect(AcceptLanguage{}.String()).To(Equal("*"))
+
+		})
+
+	})
+
+})
@@ -13,6 +13,11 @@ var (
 	ErrQMustBeNumberBetween0And1 = errors.New("rfc7231: invalid media range: q must be a number between 0 and 1")
 )
 
+// Parsing Errors for Accept-Language
+var (
+	ErrInvalidLanguageRange = errors.New("rfc7231: invalid language range")
+)
+
 type parser struct {
 	scanner scanner
 	buffer  struct {
@@ -239,3 +244,113 @@ func (p *parser) params() (map[string]string, error) {
 	return result, nil
 
 }
+
+func (p *parser) parseLanguageRanges() ([]languageRange, error) {
+
+	var result []languageRange
+
+	for {
+
+		tag, err := p.languageRange()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return []languageRange{}, err
+		}
+
+		// the default quality is 1. unlike a mediaRange, q=0 is meaningful here: "not acceptable"
+		lr := languageRange{
+			Tag: tag,
+			Q:   1,
+		}
+
+		params, err := p.params()
+
+		if err == ErrInvalidMediaRange {
+			return []languageRange{}, ErrInvalidLanguageRange
+		} else if err != nil {
+			return []languageRange{}, err
+		}
+
+		if q, ok := params["q"]; ok {
+
+			qf, err := strconv.ParseFloat(q, 64)
+
+			if err != nil || qf < 0 || qf > 1 {
+				return []languageRange{}, ErrQMustBeNumberBetween0And1
+			}
+
+			lr.Q = qf
+
+		}
+
+		result = append(result, lr)
+
+	}
+
+	return result, nil
+
+}
+
+func (p *parser) languageRange() (string, error) {
+
+	token, tag, err := p.scanIgnoreWhitespace()
+
+	if err != nil {
+		return "", err
+	}
+
+	if token == EOF {
+		return "", io.EOF
+	}
+
+	if token == COMMA {
+
+		token, tag, err = p.scanIgnoreWhitespace()
+
+		if err != nil {
+			return "", err
+		}
+
+	}
+
+	if token != WORD || !isLanguageRange(tag) {
+		return "", ErrInvalidLanguageRange
+	}
+
+	return strings.ToLower(tag), nil
+
+}
+
+// isLanguageRange returns whether the tag is a language-range as defined by RFC 4647 Sec. 2.1:
+//
+//	language-range   = (1*8ALPHA *("-" 1*8alphanum)) / "*"
+func isLanguageRange(tag string) bool {
+
+	if tag == "*" {
+		return true
+	}
+
+	for x, subtag := range strings.Split(tag, "-") {
+
+		if len(subtag) < 1 || len(subtag) > 8 {
+			return false
+		}
+
+		for _, r := range subtag {
+
+			alpha := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+			digit := r >= '0' && r <= '9'
+
+			if !alpha && (x == 0 || !digit) {
+				return false
+			}
+
+		}
+
+	}
+
+	return true
+
+}
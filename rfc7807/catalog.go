@@ -0,0 +1,221 @@
+package rfc7807
+
+import (
+	"github.com/tniswong/go.rfcx/rfc7231"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Messages holds the title and detail of a problem type in a single language. Detail is a text/template executed
+// with the Problem's extensions as its data, e.g. "Your current balance is {{.balance}}". Sensitive extensions are
+// given as RedactedValue.
+type Messages struct {
+	Title  string
+	Detail string
+}
+
+// localized holds parsed Messages for a language
+type localized struct {
+	title  string
+	detail *template.Template
+}
+
+// Catalog is a registry of localized Messages by problem type and language tag. As described by RFC 7807 Sec. 3.1,
+// the title of a problem type may be localized through content negotiation; a Catalog selects the language from the
+// request's Accept-Language header. Catalog is safe for concurrent use.
+type Catalog struct {
+	// DefaultLanguage is the language used when the request has no Accept-Language header, or none of the registered
+	// languages of a problem type are acceptable. It is never used when the header rates it q=0.
+	DefaultLanguage string
+
+	mu        sync.RWMutex
+	languages map[string][]string
+	messages  map[string]map[string]localized
+}
+
+// NewCatalog returns an empty Catalog with the given default language
+func NewCatalog(defaultLanguage string) *Catalog {
+	return &Catalog{DefaultLanguage: defaultLanguage}
+}
+
+// Register adds the Messages for a problem type in a language. An empty problemType is treated as "about:blank".
+func (c *Catalog) Register(problemType string, language string, m Messages) error {
+
+	problemType = typeOrBlank(problemType)
+	l := localized{title: m.Title}
+
+	if m.Detail != "" {
+
+		detail, err := template.New(problemType).Parse(m.Detail)
+
+		if err != nil {
+			return err
+		}
+
+		l.detail = detail
+
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.messages == nil {
+		c.languages = make(map[string][]string)
+		c.messages = make(map[string]map[string]localized)
+	}
+
+	if c.messages[problemType] == nil {
+		c.messages[problemType] = make(map[string]localized)
+	}
+
+	if _, ok := c.messages[problemType][language]; !ok {
+		c.languages[problemType] = append(c.languages[problemType], language)
+	}
+
+	c.messages[problemType][language] = l
+
+	return nil
+
+}
+
+// RegisterType adds the Messages of a ProblemType, in the DefaultLanguage first and then by language tag. Its Title
+// is registered in the DefaultLanguage unless Messages are given for it.
+func (c *Catalog) RegisterType(t ProblemType) error {
+
+	messages := t.Messages
+
+	if _, ok := messages[c.DefaultLanguage]; !ok && t.Title != "" {
+
+		messages = map[string]Messages{c.DefaultLanguage: {Title: t.Title}}
+
+		for language, m := range t.Messages {
+			messages[language] = m
+		}
+
+	}
+
+	var languages []string
+
+	for language := range messages {
+
+		if language != c.DefaultLanguage {
+			languages = append(languages, language)
+		}
+
+	}
+
+	sort.Strings(languages)
+
+	if _, ok := messages[c.DefaultLanguage]; ok {
+		languages = append([]string{c.DefaultLanguage}, languages...)
+	}
+
+	// parse every detail first so that a template error registers none of the Messages
+	for _, language := range languages {
+
+		if _, err := template.New(t.Type).Parse(messages[language].Detail); err != nil {
+			return err
+		}
+
+	}
+
+	for _, language := range languages {
+		c.Register(t.Type, language, messages[language])
+	}
+
+	return nil
+
+}
+
+// Localize returns a copy of p with its title, and detail when registered, in the most acceptable language for the
+// given Accept-Language header value, or in the DefaultLanguage when the value is empty. The selected language is
+// returned, or "", false if p's type has no Messages in an acceptable language or in a DefaultLanguage the value does
+// not exclude.
+func (c *Catalog) Localize(p Problem, acceptLanguage string) (Problem, string, bool) {
+
+	m, language, ok := c.lookup(typeOrBlank(p.Type), acceptLanguage)
+
+	if !ok {
+		return p, "", false
+	}
+
+	result := p.Clone()
+	result.Title = m.title
+
+	if m.detail != nil {
+
+		var detail strings.Builder
+
+		if err := m.detail.Execute(&detail, redactedExtensions(result)); err != nil {
+			return p, "", false
+		}
+
+		result.Detail = detail.String()
+
+	}
+
+	return result, language, true
+
+}
+
+// lookup finds the Messages for a problem type in the most acceptable language
+func (c *Catalog) lookup(problemType string, acceptLanguage string) (localized, string, bool) {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	language := c.DefaultLanguage
+
+	// an empty header would accept any language, preferring the first registered rather than the default
+	if strings.TrimSpace(acceptLanguage) != "" {
+
+		if accept, err := rfc7231.ParseAcceptLanguage(acceptLanguage); err == nil {
+
+			if acceptable, ok := accept.MostAcceptable(c.languages[problemType]); ok {
+				language = acceptable
+			} else if accept.Excludes(language) {
+				return localized{}, "", false
+			}
+
+		}
+
+	}
+
+	m, ok := c.messages[problemType][language]
+	return m, language, ok
+
+}
+
+// Handler returns an http.Handler writing p localized for each request, with the Content-Language header set to
+// the selected language
+func (c *Catalog) Handler(p Problem) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		localized, language, ok := c.Localize(p, r.Header.Get("Accept-Language"))
+
+		if ok {
+			w.Header().Set("Content-Language", language)
+		}
+
+		localized.ServeHTTP(w, r)
+
+	})
+
+}
+
+// typeOrBlank returns "about:blank" for an empty problem type. According to RFC 7807 Sec. 3.1:
+//
+//	When this member is not present, its value is assumed to be "about:blank".
+func typeOrBlank(problemType string) string {
+
+	if problemType == "" {
+		return "about:blank"
+	}
+
+	return problemType
+
+}
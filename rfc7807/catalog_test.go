@@ -0,0 +1,187 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Catalog", func() {
+
+	const outOfCredit = "https://example.com/probs/out-of-credit"
+
+	catalog := NewCatalog("en")
+	catalog.Register(outOfCredit, "en", Messages{
+		Title:  "You do not have enough credit.",
+		Detail: "Your current balance is {{.balance}}.",
+	})
+	catalog.Register(outOfCredit, "de", Messages{
+		Title:  "Sie haben nicht genügend Guthaben.",
+		Detail: "Ihr aktuelles Guthaben beträgt {{.balance}}.",
+	})
+	catalog.Register("", "fr", Messages{
+		Title: "Requête invalide",
+	})
+
+	problem := func() Problem {
+		p := Problem{Type: outOfCredit, Title: "untranslated", Status: http.StatusForbidden}
+		p.Extend("balance", 30)
+		return p
+	}
+
+	DescribeTable(
+		"Localize(p, acceptLanguage)",
+		func(acceptLanguage string, title string, detail string, language string) {
+
+			// when
+			result, resultLanguage, ok := catalog.Localize(problem(), acceptLanguage)
+
+			// then
+			Expect(ok).To(BeTrue())
+			Expect(resultLanguage).To(Equal(language))
+			Expect(result.Title).To(Equal(title))
+			Expect(result.Detail).To(Equal(detail))
+
+		},
+		Entry("no Accept-Language", "", "You do not have enough credit.", "Your current balance is 30.", "en"),
+		Entry("exact", "de", "Sie haben nicht genügend Guthaben.", "Ihr aktuelles Guthaben beträgt 30.", "de"),
+		Entry("by quality", "en;q=0.5, de-AT, de;q=0.9", "Sie haben nicht genügend Guthaben.", "Ihr aktuelles Guthaben beträgt 30.", "de"),
+		Entry("default language", "ja", "You do not have enough credit.", "Your current balance is 30.", "en"),
+		Entry("invalid header falls back to default", "1nv@lid", "You do not have enough credit.", "Your current balance is 30.", "en"),
+	)
+
+	It("should use the DefaultLanguage without Accept-Language regardless of registration order", func() {
+
+		// given
+		c := NewCatalog("en")
+		c.Register(outOfCredit, "de", Messages{Title: "Sie haben nicht genügend Guthaben."})
+		c.Register(outOfCredit, "en", Messages{Title: "You do not have enough credit."})
+
+		// when
+		result, language, ok := c.Localize(problem(), "")
+
+		// then
+		Expect(ok).To(BeTrue())
+		Expect(language).To(Equal("en"))
+		Expect(result.Title).To(Equal("You do not have enough credit."))
+
+	})
+
+	It("should not fall back to a DefaultLanguage excluded by Accept-Language", func() {
+
+		// when
+		result, language, ok := catalog.Localize(problem(), "en;q=0, fr")
+
+		// then
+		Expect(ok).To(BeFalse())
+		Expect(language).To(BeEmpty())
+		Expect(result.Title).To(Equal("untranslated"))
+
+	})
+
+	It("should treat an empty type as about:blank and keep Detail without a template", func() {
+
+		// when
+		result, language, ok := catalog.Localize(Problem{Detail: "detail"}, "fr")
+
+		// then
+		Expect(ok).To(BeTrue())
+		Expect(language).To(Equal("fr"))
+		Expect(result.Title).To(Equal("Requête invalide"))
+		Expect(result.Detail).To(Equal("detail"))
+
+	})
+
+	It("should return the Problem unchanged if no Messages are registered", func() {
+
+		// given
+		p := Problem{Type: "https://example.com/probs/unknown", Title: "title"}
+
+		// when
+		result, language, ok := catalog.Localize(p, "de")
+
+		// then
+		Expect(ok).To(BeFalse())
+		Expect(language).To(BeEmpty())
+		Expect(result.Title).To(Equal("title"))
+
+	})
+
+	It("should redact sensitive extensions in the detail", func() {
+
+		// given
+		SensitiveKeys["token"] = struct{}{}
+		defer delete(SensitiveKeys, "token")
+
+		c := NewCatalog("en")
+		c.Register(outOfCredit, "en", Messages{Detail: "token is {{.token}}"})
+
+		p := problem()
+		p.Extend("token", "s3cr3t")
+
+		// when
+		result, _, ok := c.Localize(p, "en")
+
+		// then
+		Expect(ok).To(BeTrue())
+		Expect(result.Detail).To(Equal("token is " + RedactedValue))
+
+		data, _ := json.Marshal(result)
+		Expect(string(data)).ToNot(ContainSubstring("s3cr3t"))
+
+		token, _ := result.Extension("token")
+		Expect(token).To(Equal("s3cr3t"))
+
+	})
+
+	It("should return the template error on Register", func() {
+
+		// expect
+		Expect(NewCatalog("en").Register(outOfCredit, "en", Messages{Detail: "{{"})).ToNot(Succeed())
+
+	})
+
+	It("should set Content-Language when serving a localized Problem", func() {
+
+		// given
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Language", "de")
+
+		// when
+		catalog.Handler(problem()).ServeHTTP(w, r)
+		result := Problem{}
+		json.Unmarshal(w.Body.Bytes(), &result)
+
+		// then
+		Expect(w.Code).To(Equal(http.StatusForbidden))
+		Expect(w.Header().Get("Content-Language")).To(Equal("de"))
+		Expect(result.Title).To(Equal("Sie haben nicht genügend Guthaben."))
+
+	})
+
+	It("should localize Problems written by Middleware", func() {
+
+		// given
+		m := Middleware{Catalog: catalog}
+		h := m.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.New("boom")
+		})
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Language", "fr")
+
+		// when
+		h.ServeHTTP(w, r)
+
+		// then
+		Expect(w.Header().Get("Content-Language")).To(Equal("fr"))
+		Expect(w.Body.String()).To(ContainSubstring("Requête invalide"))
+
+	})
+
+})
@@ -0,0 +1,647 @@
+package rfc7807
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// CBOR major types as described by RFC 8949 Sec. 3.1
+const (
+	cborUnsigned byte = iota
+	cborNegative
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+const (
+	// cborIndefinite is the additional information of an indefinite-length item
+	cborIndefinite = 31
+
+	// cborBreak terminates an indefinite-length item
+	cborBreak = 0xff
+
+	// cborMaxDepth limits the nesting of decoded items
+	cborMaxDepth = 512
+)
+
+var (
+	// ErrMalformedCBOR describes data that is not a single well-formed CBOR data item
+	ErrMalformedCBOR = errors.New("rfc7807: malformed CBOR")
+)
+
+// cborEntry is an encoded map entry
+type cborEntry struct {
+	key   []byte
+	value []byte
+}
+
+// cborEncoder encodes data items as described by RFC 8949 using preferred serialization, i.e. the shortest form of
+// each argument and floating-point number. Map entries of Go maps are
+// sorted bytewise by their encoded keys, so the encoding of a value is deterministic.
+type cborEncoder struct {
+	buf bytes.Buffer
+}
+
+// head writes the initial byte and argument of a data item
+func (e *cborEncoder) head(major byte, n uint64) {
+
+	major <<= 5
+
+	switch {
+	case n < 24:
+		e.buf.WriteByte(major | byte(n))
+	case n <= math.MaxUint8:
+		e.buf.Write([]byte{major | 24, byte(n)})
+	case n <= math.MaxUint16:
+		e.buf.WriteByte(major | 25)
+		binary.Write(&e.buf, binary.BigEndian, uint16(n))
+	case n <= math.MaxUint32:
+		e.buf.WriteByte(major | 26)
+		binary.Write(&e.buf, binary.BigEndian, uint32(n))
+	default:
+		e.buf.WriteByte(major | 27)
+		binary.Write(&e.buf, binary.BigEndian, n)
+	}
+
+}
+
+// int writes a signed integer
+func (e *cborEncoder) int(n int64) {
+
+	if n < 0 {
+		e.head(cborNegative, uint64(-1-n))
+	} else {
+		e.head(cborUnsigned, uint64(n))
+	}
+
+}
+
+// float writes a floating-point number in the shortest of half, single and double precision that loses no precision.
+// NaN is written as the half-precision quiet NaN.
+func (e *cborEncoder) float(f float64) {
+
+	if h, ok := toHalfFloat(f); ok {
+		e.buf.WriteByte(cborSimple<<5 | 25)
+		binary.Write(&e.buf, binary.BigEndian, h)
+		return
+	}
+
+	if float64(float32(f)) == f {
+		e.buf.WriteByte(cborSimple<<5 | 26)
+		binary.Write(&e.buf, binary.BigEndian, math.Float32bits(float32(f)))
+		return
+	}
+
+	e.buf.WriteByte(cborSimple<<5 | 27)
+	binary.Write(&e.buf, binary.BigEndian, math.Float64bits(f))
+
+}
+
+// text writes a text string
+func (e *cborEncoder) text(s string) {
+	e.head(cborText, uint64(len(s)))
+	e.buf.WriteString(s)
+}
+
+// entries writes a map of the given entries, in order
+func (e *cborEncoder) entries(entries []cborEntry) {
+
+	e.head(cborMap, uint64(len(entries)))
+
+	for _, entry := range entries {
+		e.buf.Write(entry.key)
+		e.buf.Write(entry.value)
+	}
+
+}
+
+// encode writes value. Structs, pointers and values with their own JSON representation are encoded as the generic
+// value of that representation, and json.Number as the number it holds.
+func (e *cborEncoder) encode(value interface{}) error {
+
+	if n, ok := value.(json.Number); ok {
+
+		if i, err := n.Int64(); err == nil {
+			e.int(i)
+			return nil
+		}
+
+		f, err := n.Float64()
+
+		if err != nil {
+			return err
+		}
+
+		e.float(f)
+		return nil
+
+	}
+
+	v := reflect.ValueOf(value)
+
+	if _, ok := value.(json.Marshaler); ok || v.Kind() == reflect.Struct || v.Kind() == reflect.Ptr {
+
+		generic, err := jsonGeneric(value)
+
+		if err != nil {
+			return err
+		}
+
+		return e.encode(generic)
+
+	}
+
+	switch v.Kind() {
+	case reflect.Invalid:
+		e.buf.WriteByte(cborSimple<<5 | 22)
+	case reflect.Bool:
+
+		if v.Bool() {
+			e.buf.WriteByte(cborSimple<<5 | 21)
+		} else {
+			e.buf.WriteByte(cborSimple<<5 | 20)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.int(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.head(cborUnsigned, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		e.float(v.Float())
+	case reflect.String:
+		e.text(v.String())
+	case reflect.Slice, reflect.Array:
+
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+
+			e.head(cborBytes, uint64(v.Len()))
+
+			for x := 0; x < v.Len(); x++ {
+				e.buf.WriteByte(byte(v.Index(x).Uint()))
+			}
+
+			return nil
+
+		}
+
+		e.head(cborArray, uint64(v.Len()))
+
+		for x := 0; x < v.Len(); x++ {
+			if err := e.encode(v.Index(x).Interface()); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+
+		var entries []cborEntry
+
+		for _, key := range v.MapKeys() {
+
+			var k, val cborEncoder
+
+			if err := k.encode(key.Interface()); err != nil {
+				return err
+			}
+
+			if err := val.encode(v.MapIndex(key).Interface()); err != nil {
+				return err
+			}
+
+			entries = append(entries, cborEntry{key: k.buf.Bytes(), value: val.buf.Bytes()})
+
+		}
+
+		sort.Slice(entries, func(i, j int) bool {
+			return bytes.Compare(entries[i].key, entries[j].key) < 0
+		})
+
+		e.entries(entries)
+
+	default:
+		return &json.UnsupportedTypeError{Type: v.Type()}
+	}
+
+	return nil
+
+}
+
+// cborDecoder decodes data items as described by RFC 8949. Unsigned integers are decoded as int64, or uint64 when
+// too large, negative integers as int64, floating-point numbers as float64, text strings as string, byte strings as
+// []byte, arrays as []interface{}, and maps as map[string]interface{} when every key is a text string or
+// map[interface{}]interface{} otherwise. Tags are discarded in favor of their content, and undefined is decoded as nil.
+type cborDecoder struct {
+	data  []byte
+	off   int
+	depth int
+}
+
+// head reads the initial byte and argument of a data item
+func (d *cborDecoder) head() (major byte, info byte, n uint64, err error) {
+
+	if d.off >= len(d.data) {
+		return 0, 0, 0, ErrMalformedCBOR
+	}
+
+	major, info = d.data[d.off]>>5, d.data[d.off]&0x1f
+	d.off++
+
+	var size int
+
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info == cborIndefinite:
+		return major, info, 0, nil
+	case info > 27:
+		return 0, 0, 0, ErrMalformedCBOR
+	default:
+		size = 1 << (info - 24)
+	}
+
+	if len(d.data)-d.off < size {
+		return 0, 0, 0, ErrMalformedCBOR
+	}
+
+	for _, b := range d.data[d.off : d.off+size] {
+		n = n<<8 | uint64(b)
+	}
+
+	d.off += size
+
+	return major, info, n, nil
+
+}
+
+// length checks that n items of at least one byte each can remain in the data
+func (d *cborDecoder) length(n uint64) (int, error) {
+
+	if n > uint64(len(d.data)-d.off) {
+		return 0, ErrMalformedCBOR
+	}
+
+	return int(n), nil
+
+}
+
+// breaks consumes a break, returning whether one was found
+func (d *cborDecoder) breaks() bool {
+
+	if d.off < len(d.data) && d.data[d.off] == cborBreak {
+		d.off++
+		return true
+	}
+
+	return false
+
+}
+
+// mapEntries reads a map, calling f with each key in order. f must read the value of the entry from d.
+func (d *cborDecoder) mapEntries(f func(key interface{}, d *cborDecoder) error) error {
+
+	major, info, n, err := d.head()
+
+	if err != nil {
+		return err
+	}
+
+	if major != cborMap {
+		return ErrMalformedCBOR
+	}
+
+	count, err := d.length(n)
+
+	if err != nil {
+		return err
+	}
+
+	for x := 0; info == cborIndefinite && !d.breaks() || info != cborIndefinite && x < count; x++ {
+
+		key, err := d.decode()
+
+		if err != nil {
+			return err
+		}
+
+		if err := f(key, d); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+
+}
+
+// decode reads the next data item
+func (d *cborDecoder) decode() (interface{}, error) {
+
+	if d.depth++; d.depth > cborMaxDepth {
+		return nil, ErrMalformedCBOR
+	}
+
+	defer func() { d.depth-- }()
+
+	start := d.off
+	major, info, n, err := d.head()
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUnsigned:
+
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+
+		return int64(n), nil
+
+	case cborNegative:
+
+		if n > math.MaxInt64 {
+			return nil, ErrMalformedCBOR
+		}
+
+		return -1 - int64(n), nil
+
+	case cborBytes, cborText:
+
+		str, err := d.string(major, info, n)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if major == cborBytes {
+			return str, nil
+		}
+
+		if !utf8.Valid(str) {
+			return nil, ErrMalformedCBOR
+		}
+
+		return string(str), nil
+
+	case cborArray:
+
+		count, err := d.length(n)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]interface{}, 0, count)
+
+		for x := 0; info == cborIndefinite && !d.breaks() || info != cborIndefinite && x < count; x++ {
+
+			item, err := d.decode()
+
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, item)
+
+		}
+
+		return result, nil
+
+	case cborMap:
+		d.off = start
+		return d.mapValue()
+
+	case cborTag:
+		return d.decode()
+
+	default:
+		return d.simple(info, n)
+	}
+
+}
+
+// string reads the content of a byte or text string, concatenating the chunks of an indefinite-length string
+func (d *cborDecoder) string(major byte, info byte, n uint64) ([]byte, error) {
+
+	if info != cborIndefinite {
+
+		size, err := d.length(n)
+
+		if err != nil {
+			return nil, err
+		}
+
+		d.off += size
+		return append([]byte(nil), d.data[d.off-size:d.off]...), nil
+
+	}
+
+	var result []byte
+
+	for !d.breaks() {
+
+		chunkMajor, chunkInfo, chunkN, err := d.head()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if chunkMajor != major || chunkInfo == cborIndefinite {
+			return nil, ErrMalformedCBOR
+		}
+
+		chunk, err := d.string(major, chunkInfo, chunkN)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, chunk...)
+
+	}
+
+	return result, nil
+
+}
+
+// mapValue reads a map as a map[string]interface{} when every key is a text string, or a map[interface{}]interface{}
+// otherwise
+func (d *cborDecoder) mapValue() (interface{}, error) {
+
+	var (
+		keys   []interface{}
+		values []interface{}
+		text   = true
+	)
+
+	err := d.mapEntries(func(key interface{}, d *cborDecoder) error {
+
+		if key != nil && !reflect.TypeOf(key).Comparable() {
+			return ErrMalformedCBOR
+		}
+
+		val, err := d.decode()
+
+		if err != nil {
+			return err
+		}
+
+		_, isText := key.(string)
+		text = text && isText
+
+		keys = append(keys, key)
+		values = append(values, val)
+
+		return nil
+
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if text {
+
+		result := make(map[string]interface{}, len(keys))
+
+		for x, key := range keys {
+			result[key.(string)] = values[x]
+		}
+
+		return result, nil
+
+	}
+
+	result := make(map[interface{}]interface{}, len(keys))
+
+	for x, key := range keys {
+		result[key] = values[x]
+	}
+
+	return result, nil
+
+}
+
+// simple reads a simple value or floating-point number
+func (d *cborDecoder) simple(info byte, n uint64) (interface{}, error) {
+
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		return halfFloat(uint16(n)), nil
+	case 26:
+		return float64(math.Float32frombits(uint32(n))), nil
+	case 27:
+		return math.Float64frombits(n), nil
+	default:
+		return nil, ErrMalformedCBOR
+	}
+
+}
+
+// toHalfFloat converts f to an IEEE 754 half-precision number. A bool is also returned to signify whether the
+// conversion is exact.
+func toHalfFloat(f float64) (uint16, bool) {
+
+	switch {
+	case math.IsNaN(f):
+		return 0x7e00, true
+	case float64(float32(f)) != f:
+		return 0, false
+	}
+
+	var (
+		bits     = math.Float32bits(float32(f))
+		sign     = uint16(bits>>16) & 0x8000
+		exponent = int(bits>>23&0xff) - 127
+		mantissa = bits & 0x7fffff
+	)
+
+	switch {
+	case exponent == 128:
+
+		// infinity, as NaN is handled above
+		return sign | 0x7c00, true
+
+	case exponent == -127:
+
+		// zero, as subnormal single-precision numbers are too small for half precision
+		return sign, mantissa == 0
+
+	case exponent >= -14 && exponent <= 15:
+
+		// normal, when the mantissa fits in 10 bits
+		return sign | uint16(exponent+15)<<10 | uint16(mantissa>>13), mantissa&0x1fff == 0
+
+	case exponent >= -24 && exponent < -14:
+
+		// subnormal, when the significand shifted to a multiple of 2^-24 loses no bits
+		significand, shift := mantissa|0x800000, uint(-exponent-1)
+		return sign | uint16(significand>>shift), significand&(1<<shift-1) == 0
+
+	default:
+		return 0, false
+	}
+
+}
+
+// halfFloat converts an IEEE 754 half-precision number to a float64
+func halfFloat(h uint16) float64 {
+
+	exponent, mantissa := int(h>>10&0x1f), float64(h&0x3ff)
+
+	var result float64
+
+	switch exponent {
+	case 0:
+		result = math.Ldexp(mantissa, -24)
+	case 31:
+
+		if mantissa == 0 {
+			result = math.Inf(1)
+		} else {
+			result = math.NaN()
+		}
+
+	default:
+		result = math.Ldexp(mantissa+1024, exponent-25)
+	}
+
+	if h&0x8000 != 0 {
+		return -result
+	}
+
+	return result
+
+}
+
+// cborKeyName returns the name of an extension decoded from a map key that is not a text string
+func cborKeyName(key interface{}) string {
+
+	switch k := key.(type) {
+	case int64:
+		return strconv.FormatInt(k, 10)
+	case uint64:
+		return strconv.FormatUint(k, 10)
+	default:
+		return ""
+	}
+
+}
@@ -0,0 +1,195 @@
+package rfc7807
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"math"
+	"strings"
+)
+
+// unmarshalCBOR decodes data, which must hold exactly one data item
+func unmarshalCBOR(data []byte) (interface{}, error) {
+
+	d := cborDecoder{data: data}
+	result, err := d.decode()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if d.off != len(data) {
+		return nil, ErrMalformedCBOR
+	}
+
+	return result, nil
+
+}
+
+var _ = Describe("CBOR", func() {
+
+	DescribeTable(
+		"cborEncoder should encode values as described by RFC 8949 Appendix A",
+		func(in interface{}, out string) {
+
+			// given
+			var e cborEncoder
+
+			// when
+			err := e.encode(in)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hex.EncodeToString(e.buf.Bytes())).To(Equal(out))
+
+		},
+		Entry("0", 0, "00"),
+		Entry("23", 23, "17"),
+		Entry("24", 24, "1818"),
+		Entry("1000", 1000, "1903e8"),
+		Entry("1000000", 1000000, "1a000f4240"),
+		Entry("1000000000000", int64(1000000000000), "1b000000e8d4a51000"),
+		Entry("18446744073709551615", uint64(math.MaxUint64), "1bffffffffffffffff"),
+		Entry("-1", -1, "20"),
+		Entry("-1000", -1000, "3903e7"),
+		Entry("0.0", 0.0, "f90000"),
+		Entry("-0.0", math.Copysign(0, -1), "f98000"),
+		Entry("0.5", 0.5, "f93800"),
+		Entry("1.5", 1.5, "f93e00"),
+		Entry("65504.0", 65504.0, "f97bff"),
+		Entry("5.960464477539063e-8", 5.960464477539063e-8, "f90001"),
+		Entry("0.00006103515625", 0.00006103515625, "f90400"),
+		Entry("-4.0", -4.0, "f9c400"),
+		Entry("Infinity", math.Inf(1), "f97c00"),
+		Entry("-Infinity", math.Inf(-1), "f9fc00"),
+		Entry("NaN", math.NaN(), "f97e00"),
+		Entry("65536.0", 65536.0, "fa47800000"),
+		Entry("1.0e-8", float32(1.0e-8), "fa322bcc77"),
+		Entry("100000.0", 100000.0, "fa47c35000"),
+		Entry("1.1", 1.1, "fb3ff199999999999a"),
+		Entry("json.Number integer", json.Number("30"), "181e"),
+		Entry("json.Number float", json.Number("1.1"), "fb3ff199999999999a"),
+		Entry("false", false, "f4"),
+		Entry("true", true, "f5"),
+		Entry("null", nil, "f6"),
+		Entry("byte string", []byte{1, 2, 3, 4}, "4401020304"),
+		Entry("text string", "IETF", "6449455446"),
+		Entry("unicode text string", "水", "63e6b0b4"),
+		Entry("array", []interface{}{1, []int{2, 3}, []int{4, 5}}, "8301820203820405"),
+		Entry("map", map[string]interface{}{"b": []int{2, 3}, "a": 1}, "a26161016162820203"),
+		Entry("map with sorted keys", map[interface{}]interface{}{"a": 1, 10: 2, -1: 3}, "a30a022003616101"),
+		Entry("struct", struct {
+			A int `json:"a"`
+		}{A: 1}, "a16161f93c00"),
+		Entry("json.RawMessage", json.RawMessage(`[true]`), "81f5"),
+	)
+
+	It("cborEncoder should return an error for unsupported values", func() {
+
+		// given
+		var e cborEncoder
+
+		// when
+		err := e.encode(make(chan int))
+
+		// then
+		Expect(err).To(HaveOccurred())
+
+	})
+
+	DescribeTable(
+		"unmarshalCBOR should decode values as described by RFC 8949 Appendix A",
+		func(in string, out interface{}) {
+
+			// given
+			data, _ := hex.DecodeString(in)
+
+			// when
+			result, err := unmarshalCBOR(data)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(out))
+
+		},
+		Entry("0", "00", int64(0)),
+		Entry("1000000000000", "1b000000e8d4a51000", int64(1000000000000)),
+		Entry("18446744073709551615", "1bffffffffffffffff", uint64(math.MaxUint64)),
+		Entry("-1000", "3903e7", int64(-1000)),
+		Entry("half-precision 1.5", "f93e00", 1.5),
+		Entry("half-precision 5.960464477539063e-8", "f90001", 5.960464477539063e-8),
+		Entry("half-precision -4.0", "f9c400", -4.0),
+		Entry("half-precision Infinity", "f97c00", math.Inf(1)),
+		Entry("single-precision 100000.0", "fa47c35000", 100000.0),
+		Entry("double-precision 1.1", "fb3ff199999999999a", 1.1),
+		Entry("false", "f4", false),
+		Entry("true", "f5", true),
+		Entry("byte string", "4401020304", []byte{1, 2, 3, 4}),
+		Entry("text string", "6449455446", "IETF"),
+		Entry("tagged text string", "c074323031332d30332d32315432303a30343a30305a", "2013-03-21T20:04:00Z"),
+		Entry("array", "8301820203820405", []interface{}{int64(1), []interface{}{int64(2), int64(3)}, []interface{}{int64(4), int64(5)}}),
+		Entry("map", "a26161016162820203", map[string]interface{}{"a": int64(1), "b": []interface{}{int64(2), int64(3)}}),
+		Entry("map with integer keys", "a201020304", map[interface{}]interface{}{int64(1): int64(2), int64(3): int64(4)}),
+		Entry("indefinite-length byte string", "5f42010243030405ff", []byte{1, 2, 3, 4, 5}),
+		Entry("indefinite-length text string", "7f657374726561646d696e67ff", "streaming"),
+		Entry("indefinite-length array", "9f018202039f0405ffff", []interface{}{int64(1), []interface{}{int64(2), int64(3)}, []interface{}{int64(4), int64(5)}}),
+		Entry("indefinite-length map", "bf61610161629f0203ffff", map[string]interface{}{"a": int64(1), "b": []interface{}{int64(2), int64(3)}}),
+	)
+
+	It("unmarshalCBOR should decode null and undefined as nil", func() {
+
+		// when
+		null, nullErr := unmarshalCBOR([]byte{0xf6})
+		undefined, undefinedErr := unmarshalCBOR([]byte{0xf7})
+
+		// then
+		Expect(nullErr).ToNot(HaveOccurred())
+		Expect(null).To(BeNil())
+		Expect(undefinedErr).ToNot(HaveOccurred())
+		Expect(undefined).To(BeNil())
+
+	})
+
+	It("unmarshalCBOR should decode NaN", func() {
+
+		// when
+		result, err := unmarshalCBOR([]byte{0xf9, 0x7e, 0x00})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(math.IsNaN(result.(float64))).To(BeTrue())
+
+	})
+
+	DescribeTable(
+		"unmarshalCBOR should return ErrMalformedCBOR for malformed data",
+		func(in string) {
+
+			// given
+			data, _ := hex.DecodeString(in)
+
+			// when
+			_, err := unmarshalCBOR(data)
+
+			// then
+			Expect(err).To(Equal(ErrMalformedCBOR))
+
+		},
+		Entry("empty", ""),
+		Entry("truncated argument", "19ff"),
+		Entry("truncated string", "6449"),
+		Entry("truncated array", "8301"),
+		Entry("length beyond the data", "9bffffffffffffffff"),
+		Entry("reserved additional information", "1c"),
+		Entry("unterminated indefinite-length array", "9f01"),
+		Entry("lone break", "ff"),
+		Entry("invalid UTF-8", "61ff"),
+		Entry("mismatched chunk", "7f4101ff"),
+		Entry("unhashable map key", "a18001"),
+		Entry("trailing data", "0000"),
+		Entry("excessive nesting", strings.Repeat("81", cborMaxDepth)+"00"),
+	)
+
+})
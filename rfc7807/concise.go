@@ -0,0 +1,283 @@
+package rfc7807
+
+import (
+	"net/url"
+)
+
+const (
+	// CBORMediaType is the MIME Media type for Concise Problem Details as described by RFC 9290
+	CBORMediaType = "application/concise-problem-details+cbor"
+)
+
+// Standard problem detail keys as described by RFC 9290 Sec. 2
+const (
+	cborTitleKey        int64 = -1
+	cborDetailKey       int64 = -2
+	cborInstanceKey     int64 = -3
+	cborResponseCodeKey int64 = -4
+	cborBaseURIKey      int64 = -5
+)
+
+var (
+	// CustomKeys holds the unsigned integer keys of custom problem detail entries by extension key, as described by
+	// RFC 9290 Sec. 3. Extensions listed here are encoded under their integer key rather than within the entry of the
+	// problem type. Populate it during initialization, before any Problem is marshalled or unmarshalled.
+	CustomKeys = map[string]uint64{}
+)
+
+// MarshalCBOR Marshals Concise Problem Details as described by RFC 9290. Title, Detail and Instance are encoded as
+// standard problem detail entries. Status is encoded as the equivalent CoAP response code, and omitted when there is
+// none; a status of "cxx" is represented as the code c.xx, which requires xx to be below 32. Following RFC 9290
+// Appendix A, the extensions are encoded as a custom problem detail entry keyed by the Type, in the order of
+// ExtensionKeys(), except those listed in CustomKeys, which are encoded under their integer keys. Sensitive extensions
+// are omitted.
+func (p Problem) MarshalCBOR() ([]byte, error) {
+
+	var (
+		entries   []cborEntry
+		extension []cborEntry
+		custom    []cborEntry
+	)
+
+	add := func(entries *[]cborEntry, key interface{}, value interface{}) error {
+
+		var k, v cborEncoder
+
+		if err := k.encode(key); err != nil {
+			return err
+		}
+
+		if err := v.encode(value); err != nil {
+			return err
+		}
+
+		*entries = append(*entries, cborEntry{key: k.buf.Bytes(), value: v.buf.Bytes()})
+		return nil
+
+	}
+
+	if p.Title != "" {
+		add(&entries, cborTitleKey, p.Title)
+	}
+
+	if p.Detail != "" {
+		add(&entries, cborDetailKey, p.Detail)
+	}
+
+	var zero url.URL
+	if p.Instance != zero {
+		add(&entries, cborInstanceKey, p.Instance.String())
+	}
+
+	if code, ok := coapResponseCode(p.Status); ok {
+		add(&entries, cborResponseCodeKey, code)
+	}
+
+	for _, extensionKey := range p.extensionKeys {
+
+		if isSensitive(extensionKey) {
+			continue
+		}
+
+		var err error
+
+		if customKey, ok := CustomKeys[extensionKey]; ok {
+			err = add(&custom, customKey, p.extensions[extensionKey])
+		} else {
+			err = add(&extension, extensionKey, p.extensions[extensionKey])
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+	}
+
+	if p.Type != "" || len(extension) > 0 {
+
+		var value cborEncoder
+		value.entries(extension)
+
+		var key cborEncoder
+		key.text(typeOrBlank(p.Type))
+
+		entries = append(entries, cborEntry{key: key.buf.Bytes(), value: value.buf.Bytes()})
+
+	}
+
+	var out cborEncoder
+	out.entries(append(entries, custom...))
+
+	return out.buf.Bytes(), nil
+
+}
+
+// UnmarshalCBOR unmarshalls Concise Problem Details as described by RFC 9290, the inverse of MarshalCBOR. The first
+// custom problem detail entry keyed by a URI provides the Type and the extensions in document order; further entries
+// keyed by a URI, and entries with unsigned integer keys, are added as extensions named by their key, or by the
+// extension key of CustomKeys. A relative Instance is resolved against the base URI, when present. Titles and details
+// tagged with a language are decoded without the language, and unrecognized standard problem detail entries are
+// ignored. p is only modified when data is well-formed.
+func (p *Problem) UnmarshalCBOR(data []byte) error {
+
+	var (
+		result   Problem
+		base     string
+		instance string
+		typed    bool
+		names    = make(map[uint64]string, len(CustomKeys))
+	)
+
+	for extensionKey, customKey := range CustomKeys {
+		names[customKey] = extensionKey
+	}
+
+	d := cborDecoder{data: data}
+
+	err := d.mapEntries(func(key interface{}, d *cborDecoder) error {
+
+		if uri, ok := key.(string); ok && !typed {
+
+			typed = true
+			result.Type = uri
+
+			return d.mapEntries(func(key interface{}, d *cborDecoder) error {
+
+				value, err := d.decode()
+
+				if err != nil {
+					return err
+				}
+
+				name, ok := key.(string)
+
+				if !ok {
+					name = cborKeyName(key)
+				}
+
+				if name != "" {
+					result.Extend(name, value)
+				}
+
+				return nil
+
+			})
+
+		}
+
+		value, err := d.decode()
+
+		if err != nil {
+			return err
+		}
+
+		switch key {
+		case cborTitleKey:
+			result.Title = olText(value)
+		case cborDetailKey:
+			result.Detail = olText(value)
+		case cborInstanceKey:
+			instance, _ = value.(string)
+		case cborBaseURIKey:
+			base, _ = value.(string)
+		case cborResponseCodeKey:
+
+			if code, ok := value.(int64); ok {
+				result.Status = httpStatus(code)
+			}
+
+		default:
+
+			if name, ok := key.(string); ok {
+				result.Extend(name, value)
+			} else if customKey, ok := key.(int64); ok && customKey >= 0 {
+
+				if name, ok := names[uint64(customKey)]; ok {
+					result.Extend(name, value)
+				} else {
+					result.Extend(cborKeyName(key), value)
+				}
+
+			}
+
+		}
+
+		return nil
+
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if d.off != len(data) {
+		return ErrMalformedCBOR
+	}
+
+	if instance != "" {
+
+		uri, err := url.Parse(instance)
+
+		if err != nil {
+			return ErrInvalidInstance
+		}
+
+		if baseURI, err := url.Parse(base); err == nil && base != "" {
+			uri = baseURI.ResolveReference(uri)
+		}
+
+		result.Instance = *uri
+
+	}
+
+	*p = result
+	return nil
+
+}
+
+// olText returns the text of a text string optionally tagged with a language, which is decoded as an array holding
+// the language and the text
+func olText(value interface{}) string {
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+
+		if len(v) >= 2 {
+			text, _ := v[1].(string)
+			return text
+		}
+
+	}
+
+	return ""
+
+}
+
+// coapResponseCode returns the CoAP response code c.dd, encoded as described by RFC 7252 Sec. 3, equivalent to the
+// HTTP status code cdd
+func coapResponseCode(status int) (int, bool) {
+
+	class, detail := status/100, status%100
+
+	if class < 2 || class > 5 || detail > 31 {
+		return 0, false
+	}
+
+	return class<<5 | detail, true
+
+}
+
+// httpStatus returns the HTTP status code equivalent to the CoAP response code, or 0 if it is not a response code
+func httpStatus(code int64) int {
+
+	class, detail := int(code>>5), int(code&0x1f)
+
+	if code < 0 || class < 2 || class > 5 {
+		return 0
+	}
+
+	return class*100 + detail
+
+}
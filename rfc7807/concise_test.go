@@ -0,0 +1,215 @@
+package rfc7807
+
+import (
+	"encoding/hex"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Concise Problem Details", func() {
+
+	problem := func() Problem {
+
+		p, _ := New(
+			WithType("https://example.com/probs/out-of-credit"),
+			WithTitle("You do not have enough credit."),
+			WithStatus(403),
+			WithDetail("Your current balance is 30, but that costs 50."),
+			WithInstance("/account/12345/msgs/abc"),
+			WithExtension("balance", 30),
+			WithExtension("accounts", []string{"/account/12345", "/account/67890"}),
+		)
+
+		return p
+
+	}
+
+	It("MarshalCBOR should encode standard members under their keys, and extensions under the type", func() {
+
+		// given
+		p := problem()
+
+		// when
+		data, err := p.MarshalCBOR()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		result, err := unmarshalCBOR(data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(map[interface{}]interface{}{
+			int64(-1): "You do not have enough credit.",
+			int64(-2): "Your current balance is 30, but that costs 50.",
+			int64(-3): "/account/12345/msgs/abc",
+			int64(-4): int64(4<<5 | 3),
+			"https://example.com/probs/out-of-credit": map[string]interface{}{
+				"balance":  int64(30),
+				"accounts": []interface{}{"/account/12345", "/account/67890"},
+			},
+		}))
+
+	})
+
+	It("MarshalCBOR should write standard members in key order", func() {
+
+		// given
+		p := Problem{Title: "t", Status: 404}
+
+		// when
+		data, err := p.MarshalCBOR()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hex.EncodeToString(data)).To(Equal("a2206174231884"))
+
+	})
+
+	It("MarshalCBOR should encode extensions listed in CustomKeys under their integer keys", func() {
+
+		// given
+		CustomKeys["balance"] = 7
+		defer delete(CustomKeys, "balance")
+
+		p := Problem{}
+		p.Extend("balance", map[string]interface{}{"amount": 30})
+
+		// when
+		data, err := p.MarshalCBOR()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hex.EncodeToString(data)).To(Equal("a107a166616d6f756e74181e"))
+
+	})
+
+	It("MarshalCBOR should omit sensitive extensions", func() {
+
+		// given
+		SensitiveKeys["token"] = struct{}{}
+		defer delete(SensitiveKeys, "token")
+
+		p := problem()
+		p.Extend("token", "s3cr3t")
+
+		// when
+		data, err := p.MarshalCBOR()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).ToNot(ContainSubstring("s3cr3t"))
+
+	})
+
+	It("UnmarshalCBOR(MarshalCBOR()) should round trip, preserving extension order", func() {
+
+		// given
+		p := problem()
+		data, _ := p.MarshalCBOR()
+
+		// when
+		result := Problem{}
+		err := result.UnmarshalCBOR(data)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Type).To(Equal(p.Type))
+		Expect(result.Title).To(Equal(p.Title))
+		Expect(result.Status).To(Equal(p.Status))
+		Expect(result.Detail).To(Equal(p.Detail))
+		Expect(result.Instance).To(Equal(p.Instance))
+		Expect(result.ExtensionKeys()).To(Equal([]string{"balance", "accounts"}))
+
+		balance, _ := result.Extension("balance")
+		Expect(balance).To(Equal(int64(30)))
+
+	})
+
+	It("UnmarshalCBOR should decode language-tagged text and resolve the instance against the base URI", func() {
+
+		// given
+		// {-1: 38(["en", "Title"]), -3: "msgs/abc", -5: "https://example.com/account/12345/"}
+		data, _ := hex.DecodeString("a320d8268262656e655469746c6522686d7367732f616263" +
+			"24782268747470733a2f2f6578616d706c652e636f6d2f6163636f756e742f31323334352f")
+
+		// when
+		result := Problem{}
+		err := result.UnmarshalCBOR(data)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Title).To(Equal("Title"))
+		Expect(result.Instance.String()).To(Equal("https://example.com/account/12345/msgs/abc"))
+
+	})
+
+	It("UnmarshalCBOR should add custom problem detail entries as extensions", func() {
+
+		// given
+		CustomKeys["balance"] = 7
+		defer delete(CustomKeys, "balance")
+
+		// {7: {"amount": 30}, 8: {"a": 1}, -100: 1}
+		data, _ := hex.DecodeString("a307a166616d6f756e74181e08a1616101386301")
+
+		// when
+		result := Problem{}
+		err := result.UnmarshalCBOR(data)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.ExtensionKeys()).To(Equal([]string{"balance", "8"}))
+
+		balance, _ := result.Extension("balance")
+		Expect(balance).To(Equal(map[string]interface{}{"amount": int64(30)}))
+
+	})
+
+	DescribeTable(
+		"UnmarshalCBOR should return ErrMalformedCBOR, leaving p unmodified, for malformed data",
+		func(in string) {
+
+			// given
+			data, _ := hex.DecodeString(in)
+			result := Problem{Title: "unchanged"}
+
+			// when
+			err := result.UnmarshalCBOR(data)
+
+			// then
+			Expect(err).To(Equal(ErrMalformedCBOR))
+			Expect(result).To(Equal(Problem{Title: "unchanged"}))
+
+		},
+		Entry("not a map", "8120"),
+		Entry("truncated", "a22061"),
+		Entry("trailing data", "a000"),
+		Entry("type entry not a map", "a1617801"),
+	)
+
+	DescribeTable(
+		"status should map to the equivalent CoAP response code",
+		func(status int, code int, ok bool) {
+
+			// when
+			result, found := coapResponseCode(status)
+
+			// then
+			Expect(found).To(Equal(ok))
+			Expect(result).To(Equal(code))
+
+			if ok {
+				Expect(httpStatus(int64(code))).To(Equal(status))
+			}
+
+		},
+		Entry("201", 201, 2<<5|1, true),
+		Entry("404", 404, 4<<5|4, true),
+		Entry("429", 429, 4<<5|29, true),
+		Entry("503", 503, 5<<5|3, true),
+		Entry("451", 451, 0, false),
+		Entry("100", 100, 0, false),
+		Entry("unset", 0, 0, false),
+	)
+
+})
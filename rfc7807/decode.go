@@ -0,0 +1,242 @@
+package rfc7807
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/tniswong/go.rfcx/internal/jsonobject"
+	"math"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// DecodeMode determines how a Decoder treats members that do not conform to RFC 7807
+type DecodeMode int
+
+// Decode modes
+const (
+	// Lenient ignores standard members whose values are of the wrong type, as instructed by RFC 7807 Sec. 3.1:
+	//
+	//   If such a consumer encounters a member whose value is of the wrong
+	//   type, it SHOULD ignore that member.
+	Lenient DecodeMode = iota
+
+	// Strict rejects any non-conforming document with a *DecodeError listing every violation found
+	Strict
+)
+
+// Decoding Errors
+var (
+	ErrMemberNameCase   = errors.New("rfc7807: member name differs only in case from a standard member name")
+	ErrStatusOutOfRange = errors.New("rfc7807: status must be an HTTP status code between 100 and 599")
+	ErrRelativeTypeURI  = errors.New("rfc7807: type must be an absolute URI")
+	ErrInvalidInstance  = errors.New("rfc7807: instance must be a URI reference")
+
+	errNotString = errors.New("rfc7807: not a string")
+	errNotNumber = errors.New("rfc7807: not a number")
+)
+
+// MemberError describes a violation found in a single member of a Problem Details document
+type MemberError struct {
+	Member string
+	Err    error
+}
+
+// Error implements the error interface
+func (e *MemberError) Error() string {
+	return fmt.Sprintf("%s: %q", e.Err.Error(), e.Member)
+}
+
+// Unwrap returns the underlying error
+func (e *MemberError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError reports every violation found while decoding a Problem Details document in Strict mode
+type DecodeError struct {
+	Violations []error
+}
+
+// Error implements the error interface
+func (e *DecodeError) Error() string {
+	return joinErrors(e.Violations)
+}
+
+// joinErrors joins the messages of errs with semicolons
+func joinErrors(errs []error) string {
+
+	var messages []string
+
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+
+}
+
+// Decoder decodes Problem Details JSON documents according to its Mode. Extensions are added in document order.
+type Decoder struct {
+	Mode DecodeMode
+
+	// UseNumber decodes extension numbers as json.Number rather than float64, preserving large integers such as IDs
+	UseNumber bool
+
+	// RawExtensions keeps extension values as the json.RawMessage they were decoded from, taking precedence over
+	// UseNumber
+	RawExtensions bool
+}
+
+// Unmarshal decodes the JSON document in data into p. Malformed JSON is always reported. Unlike Problem.UnmarshalJSON,
+// standard member names are matched case-sensitively, as JSON member names are.
+func (d Decoder) Unmarshal(data []byte, p *Problem) error {
+
+	in, err := jsonobject.Members(data)
+
+	if err != nil {
+		return err
+	}
+
+	// a strict decode must not modify p unless the whole document conforms
+	if d.Mode == Strict {
+
+		if violations := d.members(&Problem{}, in); len(violations) > 0 {
+			return &DecodeError{Violations: violations}
+		}
+
+	}
+
+	d.members(p, in)
+	return nil
+
+}
+
+// members decodes each member of in into p, returning the violations found
+func (d Decoder) members(p *Problem, in []jsonobject.Member) []error {
+
+	var violations []error
+
+	for _, m := range in {
+
+		if err := d.member(p, m.Name, m.Value); err != nil {
+			violations = append(violations, err)
+		}
+
+	}
+
+	return violations
+
+}
+
+// member decodes a single member into p, returning a violation if it does not conform
+func (d Decoder) member(p *Problem, k string, raw json.RawMessage) error {
+
+	value, err := checkMember(k, raw, memberRules{absoluteType: d.Mode == Strict, statusRange: d.Mode == Strict})
+
+	if err != nil {
+		return err
+	}
+
+	switch k {
+	case "type":
+		p.Type = value.(string)
+	case "title":
+		p.Title = value.(string)
+	case "status":
+		p.Status = value.(int)
+	case "detail":
+		p.Detail = value.(string)
+	case "instance":
+		p.Instance = value.(url.URL)
+	default:
+		p.Extend(k, d.extension(raw))
+	}
+
+	return nil
+
+}
+
+// extension decodes the value of an extension member
+func (d Decoder) extension(raw json.RawMessage) interface{} {
+
+	if d.RawExtensions {
+		return append(json.RawMessage(nil), raw...)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+
+	if d.UseNumber {
+		decoder.UseNumber()
+	}
+
+	var value interface{}
+	decoder.Decode(&value)
+
+	return value
+
+}
+
+// unmarshalString unmarshals raw into str, failing unless raw holds a JSON string. null is not a string.
+func unmarshalString(raw json.RawMessage, str *string) error {
+
+	if jsonKind(raw) != "string" {
+		return errNotString
+	}
+
+	return json.Unmarshal(raw, str)
+
+}
+
+// unmarshalNumber unmarshals raw into num, failing unless raw holds a JSON number. null is not a number.
+func unmarshalNumber(raw json.RawMessage, num *float64) error {
+
+	if !strings.HasPrefix(jsonKind(raw), "number") {
+		return errNotNumber
+	}
+
+	return json.Unmarshal(raw, num)
+
+}
+
+// isInt returns true if num is an integer within the range of an int, such that converting it does not overflow
+func isInt(num float64) bool {
+	return num == math.Trunc(num) && num >= math.MinInt && num < -math.MinInt
+}
+
+// typeError describes a member whose JSON value cannot be stored in a value of type t
+func typeError(field string, raw json.RawMessage, t reflect.Type) error {
+
+	return &json.UnmarshalTypeError{
+		Value:  jsonKind(raw),
+		Type:   t,
+		Field:  field,
+		Struct: "Problem",
+	}
+
+}
+
+// jsonKind describes the kind of JSON value held by raw
+func jsonKind(raw json.RawMessage) string {
+
+	if len(raw) == 0 {
+		return ""
+	}
+
+	switch raw[0] {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "bool"
+	case 'n':
+		return "null"
+	default:
+		return "number " + string(raw)
+	}
+
+}
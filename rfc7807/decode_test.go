@@ -0,0 +1,290 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"reflect"
+)
+
+var _ = Describe("Decoder", func() {
+
+	Describe("Lenient", func() {
+
+		decoder := Decoder{Mode: Lenient}
+
+		DescribeTable(
+			"Unmarshal(data, p)",
+			func(in string, out Problem) {
+
+				// given
+				result := Problem{}
+
+				// when
+				err := decoder.Unmarshal([]byte(in), &result)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(result.Type).To(Equal(out.Type))
+				Expect(result.Title).To(Equal(out.Title))
+				Expect(result.Status).To(Equal(out.Status))
+				Expect(result.Detail).To(Equal(out.Detail))
+				Expect(result.Instance).To(Equal(out.Instance))
+				Expect(result.ExtensionKeys()).To(Equal(out.ExtensionKeys()))
+
+				for _, key := range out.ExtensionKeys() {
+
+					resultValue, _ := result.Extension(key)
+					outValue, _ := out.Extension(key)
+
+					Expect(resultValue).To(Equal(outValue))
+
+				}
+
+			},
+			Entry(
+				"should decode all standard members and extensions",
+				`{
+                    "type": "https://example.com/probs/out-of-credit",
+                    "title": "title",
+                    "status": 403,
+                    "detail": "detail",
+                    "instance": "/account/12345/msgs/abc",
+                    "balance": 30
+                }`,
+				func() Problem {
+					p := Problem{
+						Type:     "https://example.com/probs/out-of-credit",
+						Title:    "title",
+						Status:   403,
+						Detail:   "detail",
+						Instance: URL("/account/12345/msgs/abc"),
+					}
+					p.Extend("balance", float64(30))
+					return p
+				}(),
+			),
+			Entry(
+				"should ignore members of the wrong type",
+				`{
+                    "type": false,
+                    "title": 1,
+                    "status": "403",
+                    "detail": null,
+                    "instance": [],
+                    "balance": 30
+                }`,
+				func() Problem {
+					p := Problem{}
+					p.Extend("balance", float64(30))
+					return p
+				}(),
+			),
+			Entry(
+				"should ignore a non-integer status",
+				`{
+                    "status": 403.5
+                }`,
+				Problem{},
+			),
+			Entry(
+				"should ignore an instance that is not a URI reference",
+				`{
+                    "instance": "this is not a uri"
+                }`,
+				Problem{},
+			),
+			Entry(
+				"should ignore a status out of the range of an int",
+				`{
+                    "status": 1e300
+                }`,
+				Problem{},
+			),
+			Entry(
+				"should ignore standard member names that differ in case",
+				`{
+                    "TYPE": "https://example.com/probs/out-of-credit",
+                    "Title": "title"
+                }`,
+				Problem{},
+			),
+			Entry(
+				"should accept a relative type and an out of range status",
+				`{
+                    "type": "/probs/out-of-credit",
+                    "status": 42
+                }`,
+				Problem{
+					Type:   "/probs/out-of-credit",
+					Status: 42,
+				},
+			),
+		)
+
+	})
+
+	Describe("Strict", func() {
+
+		decoder := Decoder{Mode: Strict}
+
+		It("should decode a conforming document", func() {
+
+			// given
+			result := Problem{}
+
+			// when
+			err := decoder.Unmarshal([]byte(`{
+                "type": "https://example.com/probs/out-of-credit",
+                "title": "title",
+                "status": 403,
+                "instance": "/account/12345/msgs/abc"
+            }`), &result)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result.Type).To(Equal("https://example.com/probs/out-of-credit"))
+			Expect(result.Title).To(Equal("title"))
+			Expect(result.Status).To(Equal(403))
+			Expect(result.Instance).To(Equal(URL("/account/12345/msgs/abc")))
+
+		})
+
+		It("should report every violation together", func() {
+
+			// given
+			result := Problem{Title: "unchanged"}
+
+			// when
+			err := decoder.Unmarshal([]byte(`{
+                "TYPE": "https://example.com/probs/out-of-credit",
+                "detail": false,
+                "instance": "Not a valid url !@#$%^&*()_+",
+                "status": 42,
+                "title": "title",
+                "type": "/probs/out-of-credit"
+            }`), &result)
+
+			// then
+			Expect(err).To(Equal(&DecodeError{
+				Violations: []error{
+					&MemberError{Member: "TYPE", Err: ErrMemberNameCase},
+					&json.UnmarshalTypeError{
+						Value:  "bool",
+						Type:   reflect.TypeOf(""),
+						Field:  "detail",
+						Struct: "Problem",
+					},
+					&MemberError{Member: "instance", Err: ErrInvalidInstance},
+					&MemberError{Member: "status", Err: ErrStatusOutOfRange},
+					&MemberError{Member: "type", Err: ErrRelativeTypeURI},
+				},
+			}))
+			Expect(result).To(Equal(Problem{Title: "unchanged"}))
+
+		})
+
+		It("should report a non-integer status", func() {
+
+			// given
+			result := Problem{}
+
+			// when
+			err := decoder.Unmarshal([]byte(`{"status": 403.5}`), &result)
+
+			// then
+			Expect(err).To(Equal(&DecodeError{
+				Violations: []error{
+					&json.UnmarshalTypeError{
+						Value:  "number 403.5",
+						Type:   reflect.TypeOf(0),
+						Field:  "status",
+						Struct: "Problem",
+					},
+				},
+			}))
+
+		})
+
+	})
+
+	Describe("extension values", func() {
+
+		const document = `{"id": 12345678901234567890, "nested": {"b": 1, "a": 2}}`
+
+		It("should decode numbers as json.Number with UseNumber", func() {
+
+			// given
+			result := Problem{}
+
+			// when
+			err := Decoder{UseNumber: true}.Unmarshal([]byte(document), &result)
+
+			// then
+			Expect(err).To(BeNil())
+
+			id, _ := result.Extension("id")
+			Expect(id).To(Equal(json.Number("12345678901234567890")))
+
+		})
+
+		It("should keep values as json.RawMessage with RawExtensions", func() {
+
+			// given
+			result := Problem{}
+
+			// when
+			err := Decoder{RawExtensions: true}.Unmarshal([]byte(document), &result)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result.ExtensionKeys()).To(Equal([]string{"id", "nested"}))
+
+			nested, _ := result.Extension("nested")
+			Expect(nested).To(Equal(json.RawMessage(`{"b": 1, "a": 2}`)))
+
+			data, err := json.Marshal(result)
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(Equal(`{"id":12345678901234567890,"nested":{"b":1,"a":2}}`))
+
+		})
+
+	})
+
+	It("should report malformed JSON in either mode", func() {
+
+		for _, mode := range []DecodeMode{Lenient, Strict} {
+
+			// given
+			result := Problem{}
+
+			// when
+			err := Decoder{Mode: mode}.Unmarshal([]byte(`{"type": `), &result)
+
+			// then
+			Expect(err).To(BeAssignableToTypeOf(&json.SyntaxError{}))
+
+		}
+
+	})
+
+	It("should describe every violation in its error message", func() {
+
+		// given
+		err := &DecodeError{
+			Violations: []error{
+				&MemberError{Member: "status", Err: ErrStatusOutOfRange},
+				&MemberError{Member: "type", Err: ErrRelativeTypeURI},
+			},
+		}
+
+		// expect
+		Expect(err.Error()).To(Equal(
+			`rfc7807: status must be an HTTP status code between 100 and 599: "status"; ` +
+				`rfc7807: type must be an absolute URI: "type"`,
+		))
+
+	})
+
+})
@@ -0,0 +1,132 @@
+package grpcstatus
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Code is a gRPC status code, with the same values as google.golang.org/grpc/codes.Code
+type Code uint32
+
+// gRPC status codes
+const (
+	OK                 Code = 0
+	Canceled           Code = 1
+	Unknown            Code = 2
+	InvalidArgument    Code = 3
+	DeadlineExceeded   Code = 4
+	NotFound           Code = 5
+	AlreadyExists      Code = 6
+	PermissionDenied   Code = 7
+	ResourceExhausted  Code = 8
+	FailedPrecondition Code = 9
+	Aborted            Code = 10
+	OutOfRange         Code = 11
+	Unimplemented      Code = 12
+	Internal           Code = 13
+	Unavailable        Code = 14
+	DataLoss           Code = 15
+	Unauthenticated    Code = 16
+)
+
+var names = map[Code]string{
+	OK:                 "OK",
+	Canceled:           "Canceled",
+	Unknown:            "Unknown",
+	InvalidArgument:    "InvalidArgument",
+	DeadlineExceeded:   "DeadlineExceeded",
+	NotFound:           "NotFound",
+	AlreadyExists:      "AlreadyExists",
+	PermissionDenied:   "PermissionDenied",
+	ResourceExhausted:  "ResourceExhausted",
+	FailedPrecondition: "FailedPrecondition",
+	Aborted:            "Aborted",
+	OutOfRange:         "OutOfRange",
+	Unimplemented:      "Unimplemented",
+	Internal:           "Internal",
+	Unavailable:        "Unavailable",
+	DataLoss:           "DataLoss",
+	Unauthenticated:    "Unauthenticated",
+}
+
+// String returns the name of the Code
+func (c Code) String() string {
+
+	if name, ok := names[c]; ok {
+		return name
+	}
+
+	return "Code(" + strconv.FormatUint(uint64(c), 10) + ")"
+
+}
+
+// httpStatuses maps each Code to its HTTP status code, as documented by google/rpc/code.proto
+var httpStatuses = map[Code]int{
+	OK:                 http.StatusOK,
+	Canceled:           499, // Client Closed Request
+	Unknown:            http.StatusInternalServerError,
+	InvalidArgument:    http.StatusBadRequest,
+	DeadlineExceeded:   http.StatusGatewayTimeout,
+	NotFound:           http.StatusNotFound,
+	AlreadyExists:      http.StatusConflict,
+	PermissionDenied:   http.StatusForbidden,
+	ResourceExhausted:  http.StatusTooManyRequests,
+	FailedPrecondition: http.StatusBadRequest,
+	Aborted:            http.StatusConflict,
+	OutOfRange:         http.StatusBadRequest,
+	Unimplemented:      http.StatusNotImplemented,
+	Internal:           http.StatusInternalServerError,
+	Unavailable:        http.StatusServiceUnavailable,
+	DataLoss:           http.StatusInternalServerError,
+	Unauthenticated:    http.StatusUnauthorized,
+}
+
+// codes maps HTTP status codes to the Code that describes them most closely. Where google/rpc/code.proto maps
+// several Codes to one HTTP status code, the most general Code is chosen.
+var codes = map[int]Code{
+	http.StatusBadRequest:                   InvalidArgument,
+	http.StatusUnauthorized:                 Unauthenticated,
+	http.StatusForbidden:                    PermissionDenied,
+	http.StatusNotFound:                     NotFound,
+	http.StatusConflict:                     Aborted,
+	http.StatusPreconditionFailed:           FailedPrecondition,
+	http.StatusRequestedRangeNotSatisfiable: OutOfRange,
+	http.StatusTooManyRequests:              ResourceExhausted,
+	499:                                     Canceled,
+	http.StatusInternalServerError:          Internal,
+	http.StatusNotImplemented:               Unimplemented,
+	http.StatusServiceUnavailable:           Unavailable,
+	http.StatusGatewayTimeout:               DeadlineExceeded,
+}
+
+// HTTPStatus returns the HTTP status code corresponding to the Code. Unrecognized Codes correspond to 500.
+func HTTPStatus(c Code) int {
+
+	if status, ok := httpStatuses[c]; ok {
+		return status
+	}
+
+	return http.StatusInternalServerError
+
+}
+
+// CodeOf returns the Code corresponding to the HTTP status code. Statuses absent from the table map by class: 2xx to
+// OK, other 4xx to FailedPrecondition, other 5xx to Internal, and anything else to Unknown.
+func CodeOf(status int) Code {
+
+	if c, ok := codes[status]; ok {
+		return c
+	}
+
+	switch status / 100 {
+	case 2:
+		return OK
+	case 4:
+		return FailedPrecondition
+	case 5:
+		return Internal
+	default:
+		return Unknown
+	}
+
+}
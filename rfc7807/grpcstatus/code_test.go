@@ -0,0 +1,53 @@
+package grpcstatus
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Code", func() {
+
+	DescribeTable(
+		"String()",
+		func(c Code, expected string) {
+			Expect(c.String()).To(Equal(expected))
+		},
+		Entry("OK", OK, "OK"),
+		Entry("NotFound", NotFound, "NotFound"),
+		Entry("unrecognized", Code(42), "Code(42)"),
+	)
+
+	DescribeTable(
+		"HTTPStatus(c)",
+		func(c Code, expected int) {
+			Expect(HTTPStatus(c)).To(Equal(expected))
+		},
+		Entry("OK", OK, 200),
+		Entry("Canceled", Canceled, 499),
+		Entry("InvalidArgument", InvalidArgument, 400),
+		Entry("DeadlineExceeded", DeadlineExceeded, 504),
+		Entry("AlreadyExists", AlreadyExists, 409),
+		Entry("ResourceExhausted", ResourceExhausted, 429),
+		Entry("Unauthenticated", Unauthenticated, 401),
+		Entry("unrecognized", Code(42), 500),
+	)
+
+	DescribeTable(
+		"CodeOf(status)",
+		func(status int, expected Code) {
+			Expect(CodeOf(status)).To(Equal(expected))
+		},
+		Entry("400", 400, InvalidArgument),
+		Entry("403", 403, PermissionDenied),
+		Entry("404", 404, NotFound),
+		Entry("409", 409, Aborted),
+		Entry("429", 429, ResourceExhausted),
+		Entry("503", 503, Unavailable),
+		Entry("other 2xx", 204, OK),
+		Entry("other 4xx", 418, FailedPrecondition),
+		Entry("other 5xx", 507, Internal),
+		Entry("unset", 0, Unknown),
+	)
+
+})
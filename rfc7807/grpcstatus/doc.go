@@ -0,0 +1,3 @@
+// Package grpcstatus converts RFC 7807 Problems to and from gRPC statuses. It mirrors the gRPC status model, see:
+// https://github.com/grpc/grpc/blob/master/doc/statuscodes.md, without depending on gRPC itself.
+package grpcstatus
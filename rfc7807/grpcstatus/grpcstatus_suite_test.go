@@ -0,0 +1,12 @@
+package grpcstatus
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestGrpcstatus(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Grpcstatus Suite")
+}
@@ -0,0 +1,143 @@
+package grpcstatus
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/tniswong/go.rfcx/rfc7807"
+	"net/http"
+	"net/url"
+)
+
+// Status mirrors google.rpc.Status, the model of a gRPC error
+type Status struct {
+	Code    Code
+	Message string
+	Details []interface{}
+}
+
+// Error implements the error interface in the format used by google.golang.org/grpc/status
+func (s Status) Error() string {
+	return fmt.Sprintf("rpc error: code = %s desc = %s", s.Code, s.Message)
+}
+
+// ProblemDetail is a Status detail carrying the members of a Problem that gRPC has no equivalent for. Detail is carried
+// as well, as the Message may hold the Title instead.
+type ProblemDetail struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	// Extensions is a JSON object of the Problem's extension members, in order
+	Extensions json.RawMessage `json:"extensions,omitempty"`
+}
+
+// FromProblem converts a Problem to a Status. The Code is derived from the Problem's Status, the Message is its
+// Detail, or its Title when Detail is empty, and the remaining members are carried by a single ProblemDetail.
+// Sensitive extensions are omitted, as they are when marshalling a Problem.
+func FromProblem(p rfc7807.Problem) (Status, error) {
+
+	// the extensions alone, as the standard members are carried by the ProblemDetail
+	extensions := p
+	extensions.Type, extensions.Title, extensions.Status, extensions.Detail = "", "", 0, ""
+	extensions.Instance = url.URL{}
+
+	data, err := json.Marshal(extensions)
+
+	if err != nil {
+		return Status{}, err
+	}
+
+	detail := ProblemDetail{
+		Type:   p.Type,
+		Title:  p.Title,
+		Status: p.Status,
+		Detail: p.Detail,
+	}
+
+	var zero url.URL
+	if p.Instance != zero {
+		detail.Instance = p.Instance.String()
+	}
+
+	if string(data) != "{}" {
+		detail.Extensions = data
+	}
+
+	message := p.Detail
+	if message == "" {
+		message = p.Title
+	}
+
+	return Status{
+		Code:    CodeOf(p.Status),
+		Message: message,
+		Details: []interface{}{detail},
+	}, nil
+
+}
+
+// ToProblem converts a Status to a Problem. When the Status carries a ProblemDetail, or a *ProblemDetail, the Problem
+// is restored from it. Otherwise, an "about:blank" Problem is returned with the HTTP status code corresponding to the
+// Code and the Message as its Detail.
+func ToProblem(s Status) (rfc7807.Problem, error) {
+
+	for _, d := range s.Details {
+
+		switch detail := d.(type) {
+		case ProblemDetail:
+			return fromDetail(s, detail)
+		case *ProblemDetail:
+
+			if detail != nil {
+				return fromDetail(s, *detail)
+			}
+
+		}
+
+	}
+
+	status := HTTPStatus(s.Code)
+
+	return rfc7807.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: s.Message,
+	}, nil
+
+}
+
+// fromDetail restores a Problem from a ProblemDetail
+func fromDetail(s Status, detail ProblemDetail) (rfc7807.Problem, error) {
+
+	var p rfc7807.Problem
+
+	if len(detail.Extensions) > 0 {
+
+		if err := p.UnmarshalJSON(detail.Extensions); err != nil {
+			return rfc7807.Problem{}, err
+		}
+
+	}
+
+	instance, err := url.Parse(detail.Instance)
+
+	if err != nil {
+		return rfc7807.Problem{}, err
+	}
+
+	p.Type = detail.Type
+	p.Title = detail.Title
+	p.Status = detail.Status
+	p.Detail = detail.Detail
+	p.Instance = *instance
+
+	if p.Status == 0 {
+		p.Status = HTTPStatus(s.Code)
+	}
+
+	return p, nil
+
+}
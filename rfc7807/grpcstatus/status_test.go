@@ -0,0 +1,202 @@
+package grpcstatus
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tniswong/go.rfcx/rfc7807"
+	"net/url"
+)
+
+var _ = Describe("Status", func() {
+
+	problem := func() rfc7807.Problem {
+
+		instance, _ := url.Parse("/account/12345/msgs/abc")
+
+		p := rfc7807.Problem{
+			Type:     "https://example.com/probs/out-of-credit",
+			Title:    "You do not have enough credit.",
+			Status:   403,
+			Detail:   "Your current balance is 30, but that costs 50.",
+			Instance: *instance,
+		}
+		p.Extend("balance", 30)
+		p.Extend("accounts", []string{"/account/12345", "/account/67890"})
+
+		return p
+
+	}
+
+	It("Error() should match the format of gRPC status errors", func() {
+
+		// given
+		s := Status{Code: NotFound, Message: "widget not found"}
+
+		// when
+		result := s.Error()
+
+		// then
+		Expect(result).To(Equal("rpc error: code = NotFound desc = widget not found"))
+
+	})
+
+	It("FromProblem(p) should derive the Code and Message, carrying the remaining members in a ProblemDetail", func() {
+
+		// given
+		p := problem()
+
+		// when
+		s, err := FromProblem(p)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(s.Code).To(Equal(PermissionDenied))
+		Expect(s.Message).To(Equal("Your current balance is 30, but that costs 50."))
+		Expect(s.Details).To(HaveLen(1))
+
+		detail := s.Details[0].(ProblemDetail)
+		Expect(detail.Type).To(Equal("https://example.com/probs/out-of-credit"))
+		Expect(detail.Title).To(Equal("You do not have enough credit."))
+		Expect(detail.Status).To(Equal(403))
+		Expect(detail.Instance).To(Equal("/account/12345/msgs/abc"))
+		Expect(string(detail.Extensions)).To(Equal(`{"balance":30,"accounts":["/account/12345","/account/67890"]}`))
+
+	})
+
+	It("FromProblem(p) should use the Title as the Message when there is no Detail", func() {
+
+		// given
+		p := rfc7807.Problem{Title: "Not Found", Status: 404}
+
+		// when
+		s, err := FromProblem(p)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(s.Message).To(Equal("Not Found"))
+		Expect(s.Details[0].(ProblemDetail).Extensions).To(BeNil())
+
+	})
+
+	It("FromProblem(p) should omit sensitive extensions", func() {
+
+		// given
+		rfc7807.SensitiveKeys["token"] = struct{}{}
+		defer delete(rfc7807.SensitiveKeys, "token")
+
+		p := problem()
+		p.Extend("token", "s3cr3t")
+
+		// when
+		s, err := FromProblem(p)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(s.Details[0].(ProblemDetail).Extensions)).ToNot(ContainSubstring("s3cr3t"))
+
+	})
+
+	It("ToProblem(FromProblem(p)) should round trip", func() {
+
+		// given
+		p := problem()
+
+		// when
+		s, _ := FromProblem(p)
+		result, err := ToProblem(s)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Type).To(Equal(p.Type))
+		Expect(result.Title).To(Equal(p.Title))
+		Expect(result.Status).To(Equal(p.Status))
+		Expect(result.Detail).To(Equal(p.Detail))
+		Expect(result.Instance).To(Equal(p.Instance))
+		Expect(result.ExtensionKeys()).To(Equal([]string{"balance", "accounts"}))
+
+		balance, _ := result.Extension("balance")
+		Expect(balance).To(Equal(float64(30)))
+
+	})
+
+	It("ToProblem(FromProblem(p)) should keep a Detail equal to the Title", func() {
+
+		// given
+		p := rfc7807.Problem{Title: "Not Found", Status: 404, Detail: "Not Found"}
+
+		// when
+		s, _ := FromProblem(p)
+		result, err := ToProblem(s)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Detail).To(Equal("Not Found"))
+
+	})
+
+	It("ToProblem(FromProblem(p)) should not invent a Detail from a Title", func() {
+
+		// given
+		p := rfc7807.Problem{Title: "Not Found", Status: 404}
+
+		// when
+		s, _ := FromProblem(p)
+		result, err := ToProblem(s)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Detail).To(BeEmpty())
+
+	})
+
+	It("ToProblem(s) should restore the Problem from a *ProblemDetail", func() {
+
+		// given
+		s := Status{
+			Code:    NotFound,
+			Message: "widget 1 does not exist",
+			Details: []interface{}{&ProblemDetail{Title: "Not Found", Detail: "widget 1 does not exist"}},
+		}
+
+		// when
+		result, err := ToProblem(s)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Title).To(Equal("Not Found"))
+		Expect(result.Status).To(Equal(404))
+		Expect(result.Detail).To(Equal("widget 1 does not exist"))
+
+	})
+
+	It("ToProblem(s) should return an about:blank Problem when there is no ProblemDetail", func() {
+
+		// given
+		s := Status{Code: Unavailable, Message: "try again later"}
+
+		// when
+		result, err := ToProblem(s)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Type).To(Equal("about:blank"))
+		Expect(result.Title).To(Equal("Service Unavailable"))
+		Expect(result.Status).To(Equal(503))
+		Expect(result.Detail).To(Equal("try again later"))
+
+	})
+
+	It("ToProblem(s) should fail when the ProblemDetail extensions are malformed", func() {
+
+		// given
+		s := Status{Code: Internal, Details: []interface{}{ProblemDetail{Extensions: []byte(`[1]`)}}}
+
+		// when
+		_, err := ToProblem(s)
+
+		// then
+		Expect(err).To(HaveOccurred())
+
+	})
+
+})
@@ -0,0 +1,67 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"github.com/tniswong/go.rfcx/rfc7231"
+	"net/http"
+)
+
+// ServeHTTP implements http.Handler by writing the Problem as the response. The representation is negotiated from
+// the request's Accept header between JSONMediaType, XMLMediaType and CBORMediaType, preferring JSONMediaType when
+// none is acceptable. The response status is the Problem's Status, or 500 when Status is unset or, as may be the case
+// for a decoded Problem, not a three-digit status code. The Retry-After and RateLimit header fields are set when the
+// Problem carries a Retry, see RetryOf.
+func (p Problem) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	var (
+		mediaType = negotiate(r)
+		body      []byte
+		err       error
+	)
+
+	switch mediaType {
+	case XMLMediaType:
+		body, err = xml.Marshal(p)
+	case CBORMediaType:
+		body, err = p.MarshalCBOR()
+	default:
+		body, err = json.Marshal(p)
+	}
+
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	status := p.Status
+	if status < 100 || status > 999 {
+		status = http.StatusInternalServerError
+	}
+
+	if retry, ok := RetryOf(p); ok {
+		retry.SetHeaders(w.Header())
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(status)
+	w.Write(body)
+
+}
+
+// negotiate returns the problem media type most acceptable to the request
+func negotiate(r *http.Request) string {
+
+	accept, err := rfc7231.ParseAccept(r.Header.Get("Accept"))
+
+	if err != nil {
+		return JSONMediaType
+	}
+
+	if mediaType, ok := accept.MostAcceptable([]string{JSONMediaType, XMLMediaType, CBORMediaType}); ok {
+		return mediaType
+	}
+
+	return JSONMediaType
+
+}
@@ -0,0 +1,76 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"math"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("ServeHTTP", func() {
+
+	DescribeTable(
+		"should negotiate the representation from the Accept header",
+		func(accept string, contentType string) {
+
+			// given
+			p := Problem{Title: "title", Status: http.StatusForbidden}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Accept", accept)
+
+			// when
+			p.ServeHTTP(w, r)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+			Expect(w.Header().Get("Content-Type")).To(Equal(contentType))
+
+			result := Problem{}
+
+			switch contentType {
+			case XMLMediaType:
+				Expect(xml.Unmarshal(w.Body.Bytes(), &result)).To(Succeed())
+			case CBORMediaType:
+				Expect(result.UnmarshalCBOR(w.Body.Bytes())).To(Succeed())
+			default:
+				Expect(json.Unmarshal(w.Body.Bytes(), &result)).To(Succeed())
+			}
+
+			Expect(result.Title).To(Equal("title"))
+
+		},
+		Entry("no Accept header", "", JSONMediaType),
+		Entry("json", JSONMediaType, JSONMediaType),
+		Entry("xml", XMLMediaType, XMLMediaType),
+		Entry("cbor", CBORMediaType, CBORMediaType),
+		Entry("xml preferred", "application/problem+json; q=0.5, application/problem+xml", XMLMediaType),
+		Entry("neither acceptable", "text/html", JSONMediaType),
+		Entry("invalid Accept header", "not a media range", JSONMediaType),
+	)
+
+	DescribeTable(
+		"should respond 500 if Status is not a status code",
+		func(status int) {
+
+			// given
+			w := httptest.NewRecorder()
+
+			// when
+			Problem{Status: status}.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+
+		},
+		Entry("unset", 0),
+		Entry("too small", 42),
+		Entry("too large", 1000),
+		Entry("negative", math.MinInt64),
+	)
+
+})
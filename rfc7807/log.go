@@ -0,0 +1,121 @@
+package rfc7807
+
+import (
+	"log/slog"
+	"net/url"
+	"strings"
+)
+
+const (
+	// RedactedValue replaces the values of sensitive extensions in logs and localized details
+	RedactedValue = "[REDACTED]"
+)
+
+var (
+	// SensitiveKeys holds the names of extension keys whose values must never reach logs or the wire. Sensitive
+	// extensions remain accessible via Problem.Extension(key), but are omitted when marshalling and redacted when
+	// logging. Names are matched case-insensitively. Populate it during initialization, before any Problem is
+	// marshalled or logged.
+	SensitiveKeys = map[string]struct{}{}
+)
+
+// isSensitive returns whether the extension key is listed in SensitiveKeys
+func isSensitive(key string) bool {
+
+	if _, sensitive := SensitiveKeys[key]; sensitive {
+		return true
+	}
+
+	for sensitiveKey := range SensitiveKeys {
+
+		if strings.EqualFold(key, sensitiveKey) {
+			return true
+		}
+
+	}
+
+	return false
+
+}
+
+// redactedExtensions returns a copy of the Problem's extensions in which the values of sensitive extensions are
+// RedactedValue
+func redactedExtensions(p Problem) map[string]interface{} {
+
+	result := make(map[string]interface{}, len(p.extensions))
+
+	for key, value := range p.extensions {
+
+		if isSensitive(key) {
+			result[key] = RedactedValue
+		} else {
+			result[key] = value
+		}
+
+	}
+
+	return result
+
+}
+
+// LogValue implements slog.LogValuer. The standard members are grouped with the extensions nested in an
+// "extensions" group, in the order of ExtensionKeys(). Sensitive extensions are redacted.
+func (p Problem) LogValue() slog.Value {
+
+	var attrs []slog.Attr
+
+	if p.Type != "" {
+		attrs = append(attrs, slog.String("type", p.Type))
+	}
+
+	if p.Title != "" {
+		attrs = append(attrs, slog.String("title", p.Title))
+	}
+
+	if p.Status != 0 {
+		attrs = append(attrs, slog.Int("status", p.Status))
+	}
+
+	if p.Detail != "" {
+		attrs = append(attrs, slog.String("detail", p.Detail))
+	}
+
+	var zero url.URL
+	if p.Instance != zero {
+		attrs = append(attrs, slog.String("instance", p.Instance.String()))
+	}
+
+	var extensions []slog.Attr
+
+	for _, extensionKey := range p.extensionKeys {
+
+		if isSensitive(extensionKey) {
+			extensions = append(extensions, slog.String(extensionKey, RedactedValue))
+		} else {
+			extensions = append(extensions, slog.Any(extensionKey, p.extensions[extensionKey]))
+		}
+
+	}
+
+	if len(extensions) > 0 {
+		attrs = append(attrs, slog.Attr{Key: "extensions", Value: slog.GroupValue(extensions...)})
+	}
+
+	return slog.GroupValue(attrs...)
+
+}
+
+// LogLevel returns the level at which a problem with the given HTTP status code is logged: slog.LevelError for
+// server errors, slog.LevelWarn for client errors, and slog.LevelInfo otherwise
+func LogLevel(status int) slog.Level {
+
+	switch {
+	case status >= 500 || status == 0:
+		return slog.LevelError
+	case status >= 400:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+
+}
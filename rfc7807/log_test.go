@@ -0,0 +1,117 @@
+package rfc7807
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Logging", func() {
+
+	BeforeEach(func() {
+		SensitiveKeys["token"] = struct{}{}
+	})
+
+	AfterEach(func() {
+		delete(SensitiveKeys, "token")
+	})
+
+	problem := func() Problem {
+
+		p := Problem{
+			Type:     "https://example.com/probs/out-of-credit",
+			Title:    "You do not have enough credit.",
+			Status:   http.StatusForbidden,
+			Instance: URL("/account/12345/msgs/abc"),
+		}
+		p.Extend("balance", 30)
+		p.Extend("Token", "s3cr3t")
+
+		return p
+
+	}
+
+	It("LogValue() should group standard members and extensions, redacting sensitive extensions", func() {
+
+		// given
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+		// when
+		logger.Info("message", "problem", problem())
+
+		// then
+		result := map[string]interface{}{}
+		Expect(json.Unmarshal(buf.Bytes(), &result)).To(Succeed())
+		Expect(result["problem"]).To(Equal(map[string]interface{}{
+			"type":     "https://example.com/probs/out-of-credit",
+			"title":    "You do not have enough credit.",
+			"status":   float64(403),
+			"instance": "/account/12345/msgs/abc",
+			"extensions": map[string]interface{}{
+				"balance": float64(30),
+				"Token":   RedactedValue,
+			},
+		}))
+
+	})
+
+	It("should omit sensitive extensions from the wire", func() {
+
+		// given
+		p := problem()
+
+		// when
+		jsonBytes, _ := json.Marshal(p)
+		xmlBytes, _ := xml.Marshal(p)
+		value, ok := p.Extension("Token")
+
+		// then
+		Expect(string(jsonBytes)).ToNot(ContainSubstring("s3cr3t"))
+		Expect(string(jsonBytes)).To(ContainSubstring(`"balance":30`))
+		Expect(string(xmlBytes)).ToNot(ContainSubstring("s3cr3t"))
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("s3cr3t"))
+
+	})
+
+	DescribeTable(
+		"LogLevel(status)",
+		func(status int, level slog.Level) {
+			Expect(LogLevel(status)).To(Equal(level))
+		},
+		Entry("unset", 0, slog.LevelError),
+		Entry("server error", 503, slog.LevelError),
+		Entry("client error", 404, slog.LevelWarn),
+		Entry("other", 207, slog.LevelInfo),
+	)
+
+	It("Middleware should log the Problems it writes", func() {
+
+		// given
+		var buf bytes.Buffer
+		m := Middleware{Logger: slog.New(slog.NewJSONHandler(&buf, nil))}
+		h := m.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.New("boom")
+		})
+
+		// when
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/widgets", nil))
+
+		// then
+		result := map[string]interface{}{}
+		Expect(json.Unmarshal(buf.Bytes(), &result)).To(Succeed())
+		Expect(result["level"]).To(Equal("ERROR"))
+		Expect(result["error"]).To(Equal("boom"))
+		Expect(result["problem"]).To(HaveKeyWithValue("instance", "/widgets"))
+
+	})
+
+})
@@ -0,0 +1,244 @@
+package rfc7807
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime/debug"
+)
+
+const (
+	// StackTraceExtensionKey is the extension key under which Middleware exposes stack traces in Development
+	StackTraceExtensionKey = "stack"
+)
+
+// HandlerFunc is an http.HandlerFunc that may return an error to be written as a Problem by Middleware
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Rule maps an error to a Problem. The bool reports whether the rule applies to the error.
+type Rule func(err error) (Problem, bool)
+
+// Is returns a Rule mapping any error matching target, as reported by errors.Is, to p
+func Is(target error, p Problem) Rule {
+
+	return func(err error) (Problem, bool) {
+
+		if errors.Is(err, target) {
+			return p, true
+		}
+
+		return Problem{}, false
+
+	}
+
+}
+
+// As returns a Rule mapping any error in the chain of the same type as example, as reported by errors.As, to the
+// Problem returned by mapper. The matched error is passed to mapper. As panics if example is nil, as it has no type.
+func As(example error, mapper func(err error) Problem) Rule {
+
+	if example == nil {
+		panic("rfc7807: As requires a non-nil example error")
+	}
+
+	t := reflect.TypeOf(example)
+
+	return func(err error) (Problem, bool) {
+
+		target := reflect.New(t)
+
+		if errors.As(err, target.Interface()) {
+			return mapper(target.Elem().Interface().(error)), true
+		}
+
+		return Problem{}, false
+
+	}
+
+}
+
+// Middleware writes Problem responses for errors returned by a HandlerFunc and for panics. Errors are mapped by the
+// first matching Rule; an error that is itself a Problem or Problems is written as is, and any other error as a 500
+// "about:blank" Problem. The Problem's Instance is set to the request URI when unset. Once the response has been started, e.g. by a
+// handler that panics midway through writing its body, no Problem is written, though it is still logged.
+type Middleware struct {
+	Rules []Rule
+
+	// Catalog, when set, localizes the Problems written for each request. See Catalog.Handler
+	Catalog *Catalog
+
+	// Logger, when set, logs every Problem written along with the error it describes, at the level given by
+	// LogLevel for its status
+	Logger *slog.Logger
+
+	// Development exposes the stack trace of recovered panics under StackTraceExtensionKey. Never enable it in
+	// production, as it discloses implementation details to clients.
+	Development bool
+}
+
+// Handler returns an http.Handler that recovers from panics in next
+func (m Middleware) Handler(next http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		rw := &responseWriter{ResponseWriter: w}
+
+		defer m.recoverPanic(rw, r)
+		next.ServeHTTP(rw, r)
+
+	})
+
+}
+
+// HandlerFunc returns an http.Handler that calls f, writing a Problem if it returns an error or panics
+func (m Middleware) HandlerFunc(f HandlerFunc) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		rw := &responseWriter{ResponseWriter: w}
+
+		defer m.recoverPanic(rw, r)
+
+		if err := f(rw, r); err != nil {
+			m.write(rw, r, m.Problem(err, r), err)
+		}
+
+	})
+
+}
+
+// Problem maps err to the Problem describing it in response to r
+func (m Middleware) Problem(err error, r *http.Request) Problem {
+
+	p := m.match(err).Clone()
+
+	var zero url.URL
+	if p.Instance == zero && r.URL != nil {
+		p.Instance = *r.URL
+	}
+
+	return p
+
+}
+
+// match finds the Problem for err
+func (m Middleware) match(err error) Problem {
+
+	for _, rule := range m.Rules {
+
+		if p, ok := rule(err); ok {
+			return p
+		}
+
+	}
+
+	var p Problem
+	if errors.As(err, &p) {
+		return p
+	}
+
+	var ps Problems
+	if errors.As(err, &ps) {
+		return ps.problem()
+	}
+
+	return blank(http.StatusInternalServerError)
+
+}
+
+// recoverPanic writes a Problem for a panic in progress. http.ErrAbortHandler is allowed to propagate.
+func (m Middleware) recoverPanic(w *responseWriter, r *http.Request) {
+
+	v := recover()
+
+	if v == nil {
+		return
+	}
+
+	if v == http.ErrAbortHandler {
+		panic(v)
+	}
+
+	err, ok := v.(error)
+	if !ok {
+		err = fmt.Errorf("rfc7807: panic: %v", v)
+	}
+
+	p := m.Problem(err, r)
+
+	if m.Development {
+		p.Extend(StackTraceExtensionKey, string(debug.Stack()))
+	}
+
+	m.write(w, r, p, err)
+
+}
+
+// write writes p, describing err, in response to r unless the response has been started. p is logged by the Logger
+// and localized by the Catalog if set.
+func (m Middleware) write(w *responseWriter, r *http.Request, p Problem, err error) {
+
+	if m.Logger != nil {
+		m.Logger.LogAttrs(
+			r.Context(),
+			LogLevel(p.Status),
+			"rfc7807: problem",
+			slog.Any("problem", p),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	if w.started {
+		return
+	}
+
+	if m.Catalog != nil {
+		m.Catalog.Handler(p).ServeHTTP(w, r)
+		return
+	}
+
+	p.ServeHTTP(w, r)
+
+}
+
+// responseWriter is an http.ResponseWriter that records whether the response has been started
+type responseWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+// WriteHeader records that the response has been started before writing the header
+func (w *responseWriter) WriteHeader(status int) {
+
+	w.started = true
+	w.ResponseWriter.WriteHeader(status)
+
+}
+
+// Write records that the response has been started before writing data
+func (w *responseWriter) Write(data []byte) (int, error) {
+
+	w.started = true
+	return w.ResponseWriter.Write(data)
+
+}
+
+// Flush records that the response has been started before flushing it, if the underlying ResponseWriter can be
+// flushed
+func (w *responseWriter) Flush() {
+
+	w.started = true
+
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+
+}
+
+// Unwrap returns the underlying ResponseWriter, for use by http.ResponseController
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,271 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"os"
+)
+
+var _ = Describe("Middleware", func() {
+
+	var errNotFound = errors.New("not found")
+
+	serve := func(h http.Handler) (*httptest.ResponseRecorder, Problem) {
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/widgets/1?verbose=true", nil))
+
+		result := Problem{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &result)).To(Succeed())
+
+		return w, result
+
+	}
+
+	Describe("HandlerFunc(f)", func() {
+
+		It("should write nothing if f returns nil", func() {
+
+			// given
+			m := Middleware{}
+			h := m.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				w.WriteHeader(http.StatusNoContent)
+				return nil
+			})
+			w := httptest.NewRecorder()
+
+			// when
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusNoContent))
+			Expect(w.Body.Len()).To(BeZero())
+
+		})
+
+		It("should map errors using the first matching Rule", func() {
+
+			// given
+			m := Middleware{
+				Rules: []Rule{
+					Is(errNotFound, Problem{Type: "https://example.com/probs/not-found", Status: http.StatusNotFound}),
+					Is(errNotFound, Problem{Status: http.StatusGone}),
+				},
+			}
+			h := m.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				return errors.Join(errors.New("loading widget"), errNotFound)
+			})
+
+			// when
+			w, result := serve(h)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+			Expect(w.Header().Get("Content-Type")).To(Equal(JSONMediaType))
+			Expect(result.Type).To(Equal("https://example.com/probs/not-found"))
+			Expect(result.Instance).To(Equal(URL("/widgets/1?verbose=true")))
+
+		})
+
+		It("should map errors by type using As", func() {
+
+			// given
+			m := Middleware{
+				Rules: []Rule{
+					As(&os.PathError{}, func(err error) Problem {
+						return Problem{Status: http.StatusBadRequest, Detail: err.(*os.PathError).Path}
+					}),
+				},
+			}
+			h := m.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				_, err := os.Open("/does/not/exist")
+				return err
+			})
+
+			// when
+			w, result := serve(h)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(result.Detail).To(Equal("/does/not/exist"))
+
+		})
+
+		It("As(nil, mapper) should panic when the Rule is built", func() {
+
+			// expect
+			Expect(func() {
+				As(nil, func(err error) Problem { return Problem{} })
+			}).To(Panic())
+
+		})
+
+		It("should write a returned Problem as is", func() {
+
+			// given
+			m := Middleware{}
+			h := m.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				return Problem{Title: "Conflict", Status: http.StatusConflict, Instance: URL("/conflicts/1")}
+			})
+
+			// when
+			w, result := serve(h)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusConflict))
+			Expect(result.Title).To(Equal("Conflict"))
+			Expect(result.Instance).To(Equal(URL("/conflicts/1")))
+
+		})
+
+		It("should write a returned Problems aggregate with its sub-problems", func() {
+
+			// given
+			m := Middleware{}
+			h := m.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				ps := Problems{Primary: Problem{Title: "Some items failed."}}
+				ps.Add(Problem{Title: "Not Found", Status: http.StatusNotFound})
+				return ps
+			})
+
+			// when
+			w, result := serve(h)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+			Expect(result.Title).To(Equal("Some items failed."))
+			Expect(result.Instance).To(Equal(URL("/widgets/1?verbose=true")))
+
+			problems, ok := result.Extension(ProblemsExtensionKey)
+			Expect(ok).To(BeTrue())
+			Expect(problems).To(HaveLen(1))
+
+		})
+
+		It("should not write a Problem for an error returned once the response has been started", func() {
+
+			// given
+			m := Middleware{}
+			h := m.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				w.Write([]byte("partial"))
+				return errors.New("boom")
+			})
+			w := httptest.NewRecorder()
+
+			// when
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal("partial"))
+
+		})
+
+		It("should write a 500 about:blank Problem for unmatched errors", func() {
+
+			// given
+			m := Middleware{}
+			h := m.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				return errors.New("boom")
+			})
+
+			// when
+			w, result := serve(h)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			Expect(result.Type).To(Equal("about:blank"))
+			Expect(result.Title).To(Equal("Internal Server Error"))
+			Expect(result.Detail).To(BeEmpty())
+
+		})
+
+	})
+
+	Describe("Handler(next)", func() {
+
+		panicking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		})
+
+		It("should recover from panics without exposing the stack trace", func() {
+
+			// when
+			w, result := serve(Middleware{}.Handler(panicking))
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			Expect(result.ExtensionKeys()).To(BeEmpty())
+
+		})
+
+		It("should expose the stack trace in Development", func() {
+
+			// when
+			_, result := serve(Middleware{Development: true}.Handler(panicking))
+
+			// then
+			stack, ok := result.Extension(StackTraceExtensionKey)
+			Expect(ok).To(BeTrue())
+			Expect(stack).To(ContainSubstring("runtime/debug.Stack"))
+
+		})
+
+		It("should not modify the Problem of a matched Rule", func() {
+
+			// given
+			p := Problem{Status: http.StatusNotFound}
+			m := Middleware{Rules: []Rule{Is(errNotFound, p)}, Development: true}
+			h := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				panic(errNotFound)
+			}))
+
+			// when
+			w, _ := serve(h)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+			Expect(p.ExtensionKeys()).To(BeEmpty())
+
+		})
+
+		It("should not write a Problem once the response has been started", func() {
+
+			// given
+			h := Middleware{}.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"partial":`))
+				panic("boom")
+			}))
+			w := httptest.NewRecorder()
+
+			// when
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal(`{"partial":`))
+
+		})
+
+		It("should let http.ErrAbortHandler propagate", func() {
+
+			// given
+			h := Middleware{}.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				panic(http.ErrAbortHandler)
+			}))
+
+			// expect
+			Expect(func() {
+				h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+			}).To(Panic())
+
+		})
+
+	})
+
+})
@@ -0,0 +1,53 @@
+package rfc7807
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// objectWriter writes a JSON object one member at a time, preserving the order members are written in
+type objectWriter struct {
+	buf     bytes.Buffer
+	members int
+}
+
+// write appends a member to the object
+func (o *objectWriter) write(name string, value interface{}) error {
+
+	key, err := json.Marshal(name)
+
+	if err != nil {
+		return err
+	}
+
+	val, err := json.Marshal(value)
+
+	if err != nil {
+		return err
+	}
+
+	if o.members == 0 {
+		o.buf.WriteByte('{')
+	} else {
+		o.buf.WriteByte(',')
+	}
+
+	o.buf.Write(key)
+	o.buf.WriteByte(':')
+	o.buf.Write(val)
+	o.members++
+
+	return nil
+
+}
+
+// bytes returns the completed object
+func (o *objectWriter) bytes() []byte {
+
+	if o.members == 0 {
+		return []byte("{}")
+	}
+
+	return append(o.buf.Bytes(), '}')
+
+}
@@ -0,0 +1,96 @@
+package rfc7807
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Option configures a Problem constructed by New(opts...) or derived by Problem.With(opts...)
+type Option func(*Problem) error
+
+// New returns a Problem configured by the given Options, or the first error returned by an Option
+func New(opts ...Option) (Problem, error) {
+	return Problem{}.With(opts...)
+}
+
+// With returns a copy of the Problem configured by the given Options, or the first error returned by an Option. The
+// copy does not share extensions with the original, which is never modified.
+func (p Problem) With(opts ...Option) (Problem, error) {
+
+	result := p.Clone()
+
+	for _, opt := range opts {
+
+		if err := opt(&result); err != nil {
+			return Problem{}, err
+		}
+
+	}
+
+	return result, nil
+
+}
+
+// WithType sets the Problem's Type
+func WithType(t string) Option {
+	return func(p *Problem) error {
+		p.Type = t
+		return nil
+	}
+}
+
+// WithTitle sets the Problem's Title
+func WithTitle(title string) Option {
+	return func(p *Problem) error {
+		p.Title = title
+		return nil
+	}
+}
+
+// WithStatus sets the Problem's Status
+func WithStatus(status int) Option {
+	return func(p *Problem) error {
+		p.Status = status
+		return nil
+	}
+}
+
+// WithDetail sets the Problem's Detail
+func WithDetail(detail string) Option {
+	return func(p *Problem) error {
+		p.Detail = detail
+		return nil
+	}
+}
+
+// WithDetailf sets the Problem's Detail, formatted according to fmt.Sprintf
+func WithDetailf(format string, args ...interface{}) Option {
+	return func(p *Problem) error {
+		p.Detail = fmt.Sprintf(format, args...)
+		return nil
+	}
+}
+
+// WithInstance parses the URI reference and sets it as the Problem's Instance
+func WithInstance(instance string) Option {
+	return func(p *Problem) error {
+
+		u, err := url.Parse(instance)
+
+		if err != nil {
+			return err
+		}
+
+		p.Instance = *u
+		return nil
+
+	}
+}
+
+// WithExtension adds an extension to the Problem as Problem.Extend(key, value) does, returning
+// ErrExtensionKeyIsReserved for reserved keys
+func WithExtension(key string, value interface{}) Option {
+	return func(p *Problem) error {
+		return p.Extend(key, value)
+	}
+}
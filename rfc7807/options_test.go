@@ -0,0 +1,75 @@
+package rfc7807
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Options", func() {
+
+	It("New(opts...) should construct a Problem configured by the Options", func() {
+
+		// when
+		result, err := New(
+			WithType("https://example.com/probs/out-of-credit"),
+			WithTitle("You do not have enough credit."),
+			WithStatus(403),
+			WithDetailf("Your current balance is %d, but that costs %d.", 30, 50),
+			WithInstance("/account/12345/msgs/abc"),
+			WithExtension("balance", 30),
+			WithExtension("accounts", []string{"/account/12345", "/account/67890"}),
+		)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Type).To(Equal("https://example.com/probs/out-of-credit"))
+		Expect(result.Title).To(Equal("You do not have enough credit."))
+		Expect(result.Status).To(Equal(403))
+		Expect(result.Detail).To(Equal("Your current balance is 30, but that costs 50."))
+		Expect(result.Instance).To(Equal(URL("/account/12345/msgs/abc")))
+		Expect(result.ExtensionKeys()).To(Equal([]string{"balance", "accounts"}))
+
+	})
+
+	It("New(opts...) should return ErrExtensionKeyIsReserved for a reserved extension key", func() {
+
+		// when
+		_, err := New(WithExtension("Status", 400))
+
+		// then
+		Expect(err).To(Equal(ErrExtensionKeyIsReserved))
+
+	})
+
+	It("New(opts...) should return the error for an invalid instance", func() {
+
+		// when
+		_, err := New(WithInstance("%zz"))
+
+		// then
+		Expect(err).To(HaveOccurred())
+
+	})
+
+	It("With(opts...) should return a copy, leaving the original unmodified", func() {
+
+		// given
+		original, _ := New(WithTitle("Original"), WithExtension("balance", 30))
+
+		// when
+		result, err := original.With(WithDetail("derived"), WithExtension("balance", 50), WithExtension("cost", 50))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Title).To(Equal("Original"))
+		Expect(result.Detail).To(Equal("derived"))
+		Expect(result.ExtensionKeys()).To(Equal([]string{"balance", "cost"}))
+
+		balance, _ := original.Extension("balance")
+		Expect(balance).To(Equal(30))
+		Expect(original.Detail).To(BeEmpty())
+		Expect(original.ExtensionKeys()).To(Equal([]string{"balance"}))
+
+	})
+
+})
@@ -3,6 +3,7 @@ package rfc7807
 import (
 	"encoding/json"
 	"errors"
+	"github.com/tniswong/go.rfcx/internal/jsonobject"
 	"net/url"
 	"reflect"
 	"strings"
@@ -92,52 +93,88 @@ func (p *Problem) Extend(key string, value interface{}) error {
 
 }
 
+// Clone returns a copy of the Problem that does not share extensions with the original. Extension values themselves are
+// not copied.
+func (p Problem) Clone() Problem {
+
+	result := p
+	result.extensionKeys = append([]string(nil), p.extensionKeys...)
+	result.extensions = make(map[string]interface{}, len(p.extensions))
+
+	for key, value := range p.extensions {
+		result.extensions[key] = value
+	}
+
+	return result
+
+}
+
 // Error implements the error interface
 func (p Problem) Error() string {
 	return p.Title
 }
 
-// MarshalJSON Marshals JSON
+// MarshalJSON Marshals JSON. The standard members are written first, followed by the extensions in the order of
+// ExtensionKeys(). Sensitive extensions are omitted.
 func (p Problem) MarshalJSON() ([]byte, error) {
 
-	out := map[string]interface{}{}
+	var out objectWriter
 
 	if p.Type != "" {
-		out["type"] = p.Type
+		out.write("type", p.Type)
 	}
 
 	if p.Title != "" {
-		out["title"] = p.Title
+		out.write("title", p.Title)
 	}
 
 	if p.Status != 0 {
-		out["status"] = p.Status
+		out.write("status", p.Status)
 	}
 
 	if p.Detail != "" {
-		out["detail"] = p.Detail
+		out.write("detail", p.Detail)
 	}
 
 	var zero url.URL
 	if p.Instance != zero {
-		out["instance"] = p.Instance.String()
+		out.write("instance", p.Instance.String())
 	}
 
 	for _, extensionKey := range p.extensionKeys {
-		out[extensionKey] = p.extensions[extensionKey]
+
+		if isSensitive(extensionKey) {
+			continue
+		}
+
+		if err := out.write(extensionKey, p.extensions[extensionKey]); err != nil {
+			return nil, err
+		}
+
 	}
 
-	return json.Marshal(out)
+	return out.bytes(), nil
 
 }
 
-// UnmarshalJSON unmarshalls JSON
+// UnmarshalJSON unmarshalls JSON. Extensions are added in document order. Standard members of the wrong type are
+// rejected; see Decoder for lenient and strict decoding as described by RFC 7807 Sec. 3.1
 func (p *Problem) UnmarshalJSON(data []byte) error {
 
-	in := map[string]interface{}{}
-	json.Unmarshal(data, &in)
+	members, err := jsonobject.Members(data)
+
+	if err != nil {
+		return err
+	}
+
+	for _, m := range members {
+
+		var (
+			k = m.Name
+			v interface{}
+		)
 
-	for k, v := range in {
+		json.Unmarshal(m.Value, &v)
 
 		switch strings.ToLower(k) {
 		case "type":
@@ -168,7 +205,7 @@ func (p *Problem) UnmarshalJSON(data []byte) error {
 
 		case "status":
 
-			if num, ok := v.(float64); ok {
+			if num, ok := v.(float64); ok && isInt(num) {
 				p.Status = int(num)
 			} else {
 				return &json.UnmarshalTypeError{
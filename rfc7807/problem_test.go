@@ -337,6 +337,30 @@ var _ = Describe("Rfc7807", func() {
 			"should return json.UnmarshalTypeError describing status field",
 			`{
                 "status": "status"
+            }`,
+			&json.UnmarshalTypeError{
+				Value:  "number",
+				Type:   reflect.TypeOf(int64(0)),
+				Field:  "status",
+				Struct: "Problem",
+			},
+		),
+		Entry(
+			"should return json.UnmarshalTypeError describing non-integer status field",
+			`{
+                "status": 500.5
+            }`,
+			&json.UnmarshalTypeError{
+				Value:  "number",
+				Type:   reflect.TypeOf(int64(0)),
+				Field:  "status",
+				Struct: "Problem",
+			},
+		),
+		Entry(
+			"should return json.UnmarshalTypeError describing an out of range status field",
+			`{
+                "status": 1e300
             }`,
 			&json.UnmarshalTypeError{
 				Value:  "number",
@@ -383,6 +407,71 @@ var _ = Describe("Rfc7807", func() {
 		),
 	)
 
+	It("MarshalJSON should write standard members first, then extensions in ExtensionKeys() order", func() {
+
+		// given
+		p := Problem{
+			Type:     "type",
+			Title:    "title",
+			Status:   500,
+			Detail:   "detail",
+			Instance: URL("about:blank"),
+		}
+		p.Extend("zulu", 1)
+		p.Extend("alpha", "a")
+		p.Extend("mike", []int{1, 2})
+
+		// when
+		result, err := json.Marshal(p)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(string(result)).To(Equal(
+			`{"type":"type","title":"title","status":500,"detail":"detail","instance":"about:blank",` +
+				`"zulu":1,"alpha":"a","mike":[1,2]}`,
+		))
+
+	})
+
+	It("MarshalJSON should write an empty object for the zero-value Problem", func() {
+
+		// when
+		result, err := json.Marshal(Problem{})
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(string(result)).To(Equal(`{}`))
+
+	})
+
+	It("UnmarshalJSON should add extensions in document order", func() {
+
+		// given
+		p := Problem{}
+
+		// when
+		err := json.Unmarshal([]byte(`{"zulu": 1, "type": "type", "alpha": "a", "mike": [1, 2]}`), &p)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(p.ExtensionKeys()).To(Equal([]string{"zulu", "alpha", "mike"}))
+
+	})
+
+	It("UnmarshalJSON should return the error describing malformed JSON", func() {
+
+		// given
+		p := Problem{}
+
+		// when
+		err := p.UnmarshalJSON([]byte(`{"type": "type"`))
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(BeAssignableToTypeOf(&json.SyntaxError{}))
+
+	})
+
 	Describe("Extend(key, value)", func() {
 
 		It("should make the value accessible via Extension(key)", func() {
@@ -438,4 +527,28 @@ var _ = Describe("Rfc7807", func() {
 
 	})
 
+	Describe("Clone()", func() {
+
+		It("should return a copy that does not share extensions with the original", func() {
+
+			// given
+			p := Problem{Title: "Original"}
+			p.Extend("balance", 30)
+
+			// when
+			result := p.Clone()
+			result.Extend("balance", 50)
+			result.Extend("accounts", []string{"/account/12345"})
+
+			// then
+			balance, _ := p.Extension("balance")
+			Expect(balance).To(Equal(30))
+			Expect(p.ExtensionKeys()).To(Equal([]string{"balance"}))
+			Expect(result.Title).To(Equal("Original"))
+			Expect(result.ExtensionKeys()).To(Equal([]string{"balance", "accounts"}))
+
+		})
+
+	})
+
 })
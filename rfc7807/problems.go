@@ -0,0 +1,213 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+)
+
+const (
+	// ProblemsExtensionKey is the extension key under which Problems carries its sub-problems
+	ProblemsExtensionKey = "problems"
+)
+
+// Problems is an aggregate reporting several problems in a single response, such as the per-item failures of a batch
+// request. Primary describes the response as a whole, and each of Problems is a full Problem with its own extensions.
+// It is represented as the primary problem with the sub-problems in an array extension under ProblemsExtensionKey.
+type Problems struct {
+	Primary  Problem
+	Problems []Problem
+}
+
+// Add appends a sub-problem
+func (ps *Problems) Add(p Problem) {
+	ps.Problems = append(ps.Problems, p)
+}
+
+// Status returns the HTTP status code of the aggregate. The Primary's Status is used when set. Otherwise, when the
+// sub-problems share a status code, that code is used, and when they differ, the generic code of the most severe
+// class: 500 if any is a server error, or else 400. RFC 7807 Sec. 3 suggests 207 (Multi-Status) for sub-problems
+// that do not share a status code, but that describes a WebDAV multistatus body rather than a problem document, and
+// would present a failed batch as a success.
+func (ps Problems) Status() int {
+
+	if ps.Primary.Status != 0 {
+		return ps.Primary.Status
+	}
+
+	if len(ps.Problems) == 0 {
+		return 0
+	}
+
+	var (
+		status      = ps.Problems[0].Status
+		sameCode    = true
+		serverError = false
+	)
+
+	for _, p := range ps.Problems {
+		sameCode = sameCode && p.Status == status
+		serverError = serverError || p.Status/100 == 5
+	}
+
+	switch {
+	case sameCode:
+		return status
+	case serverError:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+
+}
+
+// Error implements the error interface
+func (ps Problems) Error() string {
+	return ps.Primary.Error()
+}
+
+// problem returns the Primary carrying the sub-problems and the aggregate Status
+func (ps Problems) problem() Problem {
+
+	p := ps.Primary.Clone()
+	p.Status = ps.Status()
+
+	if len(ps.Problems) > 0 {
+		p.Extend(ProblemsExtensionKey, ps.Problems)
+	}
+
+	return p
+
+}
+
+// ServeHTTP implements http.Handler by writing the aggregate as the response. See Problem.ServeHTTP
+func (ps Problems) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ps.problem().ServeHTTP(w, r)
+}
+
+// MarshalJSON Marshals JSON
+func (ps Problems) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ps.problem())
+}
+
+// UnmarshalJSON unmarshalls JSON
+func (ps *Problems) UnmarshalJSON(data []byte) error {
+
+	var (
+		primary Problem
+		in      struct {
+			Problems []Problem `json:"problems"`
+		}
+	)
+
+	if err := primary.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	primary.Extend(ProblemsExtensionKey, nil)
+
+	ps.Primary = primary
+	ps.Problems = in.Problems
+
+	return nil
+
+}
+
+// MarshalXML Marshals XML. Sub-problems are encoded as "i" elements of a "problems" element, each with its members in
+// order, as by Problem.MarshalXML.
+func (ps Problems) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return ps.problem().MarshalXML(e, start)
+}
+
+// UnmarshalXML unmarshalls XML. Each "i" element of the "problems" element is decoded as by Problem.UnmarshalXML,
+// keeping its members in document order.
+func (ps *Problems) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+
+	var (
+		primary  Problem
+		problems []Problem
+	)
+
+	for {
+
+		token, err := d.Token()
+
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+
+			if t.Name.Local == ProblemsExtensionKey {
+
+				if problems, err = unmarshalXMLProblems(d); err != nil {
+					return err
+				}
+
+				continue
+
+			}
+
+			value, err := decodeXMLValue(d)
+
+			if err != nil {
+				return err
+			}
+
+			if err := primary.xmlMember(t.Name.Local, value); err != nil {
+				return err
+			}
+
+		case xml.EndElement:
+
+			ps.Primary = primary
+			ps.Problems = problems
+
+			return nil
+
+		}
+
+	}
+
+}
+
+// unmarshalXMLProblems decodes the "i" elements of a "problems" element, up to and including its end element
+func unmarshalXMLProblems(d *xml.Decoder) ([]Problem, error) {
+
+	var problems []Problem
+
+	for {
+
+		token, err := d.Token()
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+
+			if t.Name.Local != "i" {
+				return nil, xml.UnmarshalError("rfc7807: problems must contain i elements only")
+			}
+
+			var p Problem
+
+			if err := p.UnmarshalXML(d, t); err != nil {
+				return nil, err
+			}
+
+			problems = append(problems, p)
+
+		case xml.EndElement:
+			return problems, nil
+		}
+
+	}
+
+}
@@ -0,0 +1,186 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Problems", func() {
+
+	DescribeTable(
+		"Status()",
+		func(primary int, statuses []int, out int) {
+
+			// given
+			ps := Problems{Primary: Problem{Status: primary}}
+			for _, status := range statuses {
+				ps.Add(Problem{Status: status})
+			}
+
+			// expect
+			Expect(ps.Status()).To(Equal(out))
+
+		},
+		Entry("primary status wins", 422, []int{404, 500}, 422),
+		Entry("same code", 0, []int{404, 404}, 404),
+		Entry("same client error class", 0, []int{404, 409}, 400),
+		Entry("same server error class", 0, []int{502, 503}, 500),
+		Entry("different classes", 0, []int{201, 409}, 400),
+		Entry("different classes, including a server error", 0, []int{404, 503}, 500),
+		Entry("no sub-problems", 0, []int{}, 0),
+	)
+
+	batch := func() Problems {
+
+		ps := Problems{Primary: Problem{Type: "https://example.com/probs/batch", Title: "Some items failed."}}
+
+		first := Problem{Type: "https://example.com/probs/out-of-credit", Status: 403}
+		first.Extend("balance", 30)
+		ps.Add(first)
+
+		ps.Add(Problem{Title: "Not Found", Status: 404, Instance: URL("/items/2")})
+
+		return ps
+
+	}
+
+	It("should marshal and unmarshal JSON", func() {
+
+		// when
+		data, err := json.Marshal(batch())
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(data).To(MatchJSON(`{
+            "type": "https://example.com/probs/batch",
+            "title": "Some items failed.",
+            "status": 400,
+            "problems": [
+                {"type": "https://example.com/probs/out-of-credit", "status": 403, "balance": 30},
+                {"title": "Not Found", "status": 404, "instance": "/items/2"}
+            ]
+        }`))
+
+		// when
+		result := Problems{}
+		err = json.Unmarshal(data, &result)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result.Primary.Title).To(Equal("Some items failed."))
+		Expect(result.Primary.ExtensionKeys()).To(BeEmpty())
+		Expect(result.Problems).To(HaveLen(2))
+		Expect(result.Problems[0].Type).To(Equal("https://example.com/probs/out-of-credit"))
+
+		balance, _ := result.Problems[0].Extension("balance")
+		Expect(balance).To(Equal(float64(30)))
+		Expect(result.Problems[1].Instance).To(Equal(URL("/items/2")))
+
+	})
+
+	It("should marshal and unmarshal XML", func() {
+
+		// when
+		data, err := xml.Marshal(batch())
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(ContainSubstring(
+			`<problems><i><type>https://example.com/probs/out-of-credit</type><status>403</status>` +
+				`<balance>30</balance></i>`,
+		))
+
+		// when
+		result := Problems{}
+		err = xml.Unmarshal(data, &result)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result.Primary.Status).To(Equal(400))
+		Expect(result.Primary.ExtensionKeys()).To(BeEmpty())
+		Expect(result.Problems).To(HaveLen(2))
+		Expect(result.Problems[0].Status).To(Equal(403))
+		Expect(result.Problems[1].Instance).To(Equal(URL("/items/2")))
+
+	})
+
+	It("should keep the order of sub-problem members through XML", func() {
+
+		// given
+		sub := Problem{Title: "Invalid item", Status: 422}
+		sub.Extend("zone", "eu")
+		sub.Extend("amount", 3)
+		sub.Extend("item", "/items/7")
+
+		ps := Problems{Problems: []Problem{sub}}
+
+		// when
+		data, err := xml.Marshal(ps)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(ContainSubstring(
+			`<i><title>Invalid item</title><status>422</status><zone>eu</zone><amount>3</amount>` +
+				`<item>/items/7</item></i>`,
+		))
+
+		for x := 0; x < 10; x++ {
+
+			// when
+			result := Problems{}
+			err := xml.Unmarshal(data, &result)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result.Problems).To(HaveLen(1))
+			Expect(result.Problems[0].Title).To(Equal("Invalid item"))
+			Expect(result.Problems[0].ExtensionKeys()).To(Equal([]string{"zone", "amount", "item"}))
+
+		}
+
+	})
+
+	It("should reject elements other than i in the problems element", func() {
+
+		// when
+		err := xml.Unmarshal([]byte(`<problem xmlns="urn:ietf:rfc:7807"><problems><x/></problems></problem>`), &Problems{})
+
+		// then
+		Expect(err).To(HaveOccurred())
+
+	})
+
+	It("should not modify the Primary when marshalling", func() {
+
+		// given
+		ps := batch()
+
+		// when
+		json.Marshal(ps)
+
+		// then
+		Expect(ps.Primary.ExtensionKeys()).To(BeEmpty())
+		Expect(ps.Primary.Status).To(BeZero())
+
+	})
+
+	It("should serve the aggregate with its Status", func() {
+
+		// given
+		w := httptest.NewRecorder()
+
+		// when
+		batch().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/batch", nil))
+
+		// then
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+		Expect(w.Header().Get("Content-Type")).To(Equal(JSONMediaType))
+
+	})
+
+})
@@ -0,0 +1,91 @@
+package rfc7807
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+)
+
+var (
+	// ErrResponseBodyTooLarge describes a problem document that exceeds MaxResponseBodySize
+	ErrResponseBodyTooLarge = errors.New("rfc7807: response body exceeds the maximum problem document size")
+
+	// ErrNotAProblem describes a response with neither a problem document nor an error status code
+	ErrNotAProblem = errors.New("rfc7807: the response does not carry a problem")
+
+	// MaxResponseBodySize is the maximum number of bytes FromResponse will read from a response body
+	MaxResponseBodySize int64 = 1 << 20
+)
+
+// FromResponse decodes the Problem carried by an HTTP response. The body is decoded when the Content-Type is
+// JSONMediaType, XMLMediaType or CBORMediaType, with or without parameters, and the Problem's Status is taken from the
+// response when the document omits it. Any other error response, or one without a body, yields an "about:blank"
+// Problem as described by RFC 7807 Sec. 4.2, while a successful, informational or redirection response yields
+// ErrNotAProblem.
+//
+// The response body is read, but not closed.
+func FromResponse(resp *http.Response) (Problem, error) {
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	isProblem := mediaType == JSONMediaType || mediaType == XMLMediaType || mediaType == CBORMediaType
+
+	if err != nil || !isProblem || resp.Body == nil {
+
+		if resp.StatusCode < 400 {
+			return Problem{}, ErrNotAProblem
+		}
+
+		return blank(resp.StatusCode), nil
+
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxResponseBodySize+1))
+
+	if err != nil {
+		return Problem{}, err
+	}
+
+	if int64(len(body)) > MaxResponseBodySize {
+		return Problem{}, ErrResponseBodyTooLarge
+	}
+
+	var p Problem
+
+	switch mediaType {
+	case JSONMediaType:
+		err = p.UnmarshalJSON(body)
+	case CBORMediaType:
+		err = p.UnmarshalCBOR(body)
+	default:
+		err = xml.Unmarshal(body, &p)
+	}
+
+	if err != nil {
+		return Problem{}, err
+	}
+
+	if p.Status == 0 {
+		p.Status = resp.StatusCode
+	}
+
+	return p, nil
+
+}
+
+// blank returns an "about:blank" Problem for the given HTTP status code. According to RFC 7807 Sec. 4.2:
+//
+//	When "about:blank" is used, the title SHOULD be the same as the
+//	recommended HTTP status phrase for that code (e.g., "Not Found" for
+//	404, and so on), although it MAY be localized to suit client
+//	preferences (expressed with the Accept-Language request header).
+func blank(status int) Problem {
+
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+
+}
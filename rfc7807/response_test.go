@@ -0,0 +1,142 @@
+package rfc7807
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var _ = Describe("FromResponse", func() {
+
+	response := func(status int, contentType string, body string) *http.Response {
+
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{contentType}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+
+	}
+
+	DescribeTable(
+		"should decode problem documents",
+		func(resp *http.Response, out Problem) {
+
+			// when
+			result, err := FromResponse(resp)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result.Type).To(Equal(out.Type))
+			Expect(result.Title).To(Equal(out.Title))
+			Expect(result.Status).To(Equal(out.Status))
+
+		},
+		Entry(
+			"json",
+			response(403, JSONMediaType, `{"type": "https://example.com/probs/out-of-credit", "status": 403}`),
+			Problem{Type: "https://example.com/probs/out-of-credit", Status: 403},
+		),
+		Entry(
+			"json with parameters",
+			response(403, JSONMediaType+"; charset=utf-8", `{"type": "https://example.com/probs/out-of-credit"}`),
+			Problem{Type: "https://example.com/probs/out-of-credit", Status: 403},
+		),
+		Entry(
+			"xml",
+			response(403, XMLMediaType, `<problem xmlns="urn:ietf:rfc:7807"><title>title</title></problem>`),
+			Problem{Title: "title", Status: 403},
+		),
+		Entry(
+			"cbor",
+			response(403, CBORMediaType, "\xa1\x20\x65title"),
+			Problem{Title: "title", Status: 403},
+		),
+		Entry(
+			"status present in the document",
+			response(500, JSONMediaType, `{"status": 503}`),
+			Problem{Status: 503},
+		),
+		Entry(
+			"not a problem document",
+			response(404, "text/html", `<html></html>`),
+			Problem{Type: "about:blank", Title: "Not Found", Status: 404},
+		),
+		Entry(
+			"no content type",
+			response(502, "", ``),
+			Problem{Type: "about:blank", Title: "Bad Gateway", Status: 502},
+		),
+	)
+
+	It("should decode a problem document carried by a successful response", func() {
+
+		// when
+		result, err := FromResponse(response(200, JSONMediaType, `{"title": "title"}`))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Title).To(Equal("title"))
+		Expect(result.Status).To(Equal(200))
+
+	})
+
+	DescribeTable(
+		"should return ErrNotAProblem for a response that is neither a problem document nor an error",
+		func(status int, contentType string) {
+
+			// when
+			result, err := FromResponse(response(status, contentType, `{"partial": true}`))
+
+			// then
+			Expect(err).To(Equal(ErrNotAProblem))
+			Expect(result).To(Equal(Problem{}))
+
+		},
+		Entry("200", 200, "application/json"),
+		Entry("204", 204, ""),
+		Entry("302", 302, "text/html"),
+	)
+
+	It("should not read a missing body", func() {
+
+		// given
+		resp := response(503, JSONMediaType, ``)
+		resp.Body = nil
+
+		// when
+		result, err := FromResponse(resp)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(Problem{Type: "about:blank", Title: "Service Unavailable", Status: 503}))
+
+	})
+
+	It("should return the error describing a malformed problem document", func() {
+
+		// when
+		_, err := FromResponse(response(400, JSONMediaType, `{"type": `))
+
+		// then
+		Expect(err).To(HaveOccurred())
+
+	})
+
+	It("should return ErrResponseBodyTooLarge if the body exceeds MaxResponseBodySize", func() {
+
+		// given
+		body := `{"detail": "` + strings.Repeat("x", int(MaxResponseBodySize)) + `"}`
+
+		// when
+		_, err := FromResponse(response(400, JSONMediaType, body))
+
+		// then
+		Expect(err).To(Equal(ErrResponseBodyTooLarge))
+
+	})
+
+})
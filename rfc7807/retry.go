@@ -0,0 +1,229 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// RetryAfterExtensionKey is the extension key carrying the number of seconds to wait before retrying
+	RetryAfterExtensionKey = "retryAfter"
+
+	// RateLimitExtensionKey is the extension key carrying the client's RateLimit
+	RateLimitExtensionKey = "rateLimit"
+)
+
+var (
+	// ErrInvalidRetryAfter describes a Retry-After header field that is neither an HTTP-date nor a number of seconds
+	ErrInvalidRetryAfter = errors.New("rfc7807: Retry-After must be an HTTP-date or a non-negative number of seconds")
+
+	// ErrInvalidRateLimit describes a RateLimit header field that is not a non-negative integer
+	ErrInvalidRateLimit = errors.New("rfc7807: RateLimit header fields must be non-negative integers")
+)
+
+// RateLimit describes the quota of a client as conveyed by the RateLimit-Limit, RateLimit-Remaining and
+// RateLimit-Reset header fields of draft-ietf-httpapi-ratelimit-headers. As an extension, it is represented as an
+// object with "limit", "remaining" and "reset" members, where reset is a number of seconds.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Duration
+}
+
+// rateLimitJSON is the JSON representation of RateLimit
+type rateLimitJSON struct {
+	Limit     int `json:"limit"`
+	Remaining int `json:"remaining"`
+	Reset     int `json:"reset"`
+}
+
+// MarshalJSON Marshals JSON
+func (rl RateLimit) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rateLimitJSON{Limit: rl.Limit, Remaining: rl.Remaining, Reset: seconds(rl.Reset)})
+}
+
+// UnmarshalJSON unmarshalls JSON
+func (rl *RateLimit) UnmarshalJSON(data []byte) error {
+
+	var in rateLimitJSON
+
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*rl = RateLimit{Limit: in.Limit, Remaining: in.Remaining, Reset: time.Duration(in.Reset) * time.Second}
+	return nil
+
+}
+
+// Retry describes when a request may be retried
+type Retry struct {
+	// After is the delay before retrying, or 0 if unspecified
+	After time.Duration
+
+	// RateLimit is the client's quota, or nil if unspecified
+	RateLimit *RateLimit
+}
+
+// TooManyRequests returns a 429 "about:blank" Problem carrying r as extensions
+func TooManyRequests(r Retry) Problem {
+	return r.problem(http.StatusTooManyRequests)
+}
+
+// ServiceUnavailable returns a 503 "about:blank" Problem carrying r as extensions
+func ServiceUnavailable(r Retry) Problem {
+	return r.problem(http.StatusServiceUnavailable)
+}
+
+// problem returns an "about:blank" Problem with the given status carrying r as extensions
+func (r Retry) problem(status int) Problem {
+
+	p := blank(status)
+
+	if r.After > 0 {
+		p.Extend(RetryAfterExtensionKey, seconds(r.After))
+	}
+
+	if r.RateLimit != nil {
+		p.Extend(RateLimitExtensionKey, *r.RateLimit)
+	}
+
+	return p
+
+}
+
+// SetHeaders sets the Retry-After header field, as a number of seconds, and the RateLimit header fields described by
+// r. Problem.ServeHTTP sets them for any Problem carrying a Retry.
+func (r Retry) SetHeaders(h http.Header) {
+
+	if r.After > 0 {
+		h.Set("Retry-After", strconv.Itoa(seconds(r.After)))
+	}
+
+	if r.RateLimit != nil {
+		h.Set("RateLimit-Limit", strconv.Itoa(r.RateLimit.Limit))
+		h.Set("RateLimit-Remaining", strconv.Itoa(r.RateLimit.Remaining))
+		h.Set("RateLimit-Reset", strconv.Itoa(seconds(r.RateLimit.Reset)))
+	}
+
+}
+
+// RetryOf returns the Retry carried by the Problem's extensions, whether set by TooManyRequests and
+// ServiceUnavailable or decoded from a document. The bool reports whether either extension is present and valid.
+func RetryOf(p Problem) (Retry, bool) {
+
+	var (
+		r     Retry
+		found bool
+	)
+
+	if value, ok := p.Extension(RetryAfterExtensionKey); ok {
+
+		var after float64
+
+		if generic, err := jsonGeneric(value); err == nil {
+			after, ok = generic.(float64)
+		}
+
+		if ok && after >= 0 {
+			r.After = time.Duration(after * float64(time.Second))
+			found = true
+		}
+
+	}
+
+	if value, ok := p.Extension(RateLimitExtensionKey); ok {
+
+		var rl RateLimit
+
+		if data, err := json.Marshal(value); err == nil && json.Unmarshal(data, &rl) == nil {
+			r.RateLimit = &rl
+			found = true
+		}
+
+	}
+
+	return r, found
+
+}
+
+// ParseRetry reads the Retry-After and RateLimit header fields of a response. Retry-After may be an HTTP-date, in
+// which case After is the time remaining until then, or 0 if it has passed. RateLimit is only set when
+// RateLimit-Limit is present; a RateLimit-Limit carrying quota policies, e.g. "100, 100;w=60", yields its first value.
+func ParseRetry(h http.Header) (Retry, error) {
+
+	var r Retry
+
+	if value := strings.TrimSpace(h.Get("Retry-After")); value != "" {
+
+		if delay, err := strconv.Atoi(value); err == nil && delay >= 0 {
+			r.After = time.Duration(delay) * time.Second
+		} else if date, err := http.ParseTime(value); err == nil {
+			r.After = time.Until(date)
+		} else {
+			return Retry{}, ErrInvalidRetryAfter
+		}
+
+		if r.After < 0 {
+			r.After = 0
+		}
+
+	}
+
+	if h.Get("RateLimit-Limit") == "" {
+		return r, nil
+	}
+
+	limit, err := rateLimitField(h, "RateLimit-Limit")
+
+	if err != nil {
+		return Retry{}, err
+	}
+
+	remaining, err := rateLimitField(h, "RateLimit-Remaining")
+
+	if err != nil {
+		return Retry{}, err
+	}
+
+	reset, err := rateLimitField(h, "RateLimit-Reset")
+
+	if err != nil {
+		return Retry{}, err
+	}
+
+	r.RateLimit = &RateLimit{Limit: limit, Remaining: remaining, Reset: time.Duration(reset) * time.Second}
+
+	return r, nil
+
+}
+
+// rateLimitField reads the first value of a RateLimit header field, ignoring any parameters. An absent field is 0.
+func rateLimitField(h http.Header, name string) (int, error) {
+
+	value := h.Get(name)
+
+	if value == "" {
+		return 0, nil
+	}
+
+	value = strings.TrimSpace(strings.SplitN(strings.SplitN(value, ",", 2)[0], ";", 2)[0])
+	n, err := strconv.Atoi(value)
+
+	if err != nil || n < 0 {
+		return 0, ErrInvalidRateLimit
+	}
+
+	return n, nil
+
+}
+
+// seconds returns d as a whole number of seconds, rounded up so that a client never retries early
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,183 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("Retry", func() {
+
+	rateLimit := &RateLimit{Limit: 100, Remaining: 0, Reset: 60 * time.Second}
+
+	It("TooManyRequests(r) should return a 429 Problem carrying r as extensions", func() {
+
+		// when
+		p := TooManyRequests(Retry{After: 1500 * time.Millisecond, RateLimit: rateLimit})
+
+		// then
+		Expect(p.Type).To(Equal("about:blank"))
+		Expect(p.Title).To(Equal("Too Many Requests"))
+		Expect(p.Status).To(Equal(429))
+
+		data, _ := json.Marshal(p)
+		Expect(data).To(MatchJSON(`{
+			"type": "about:blank",
+			"title": "Too Many Requests",
+			"status": 429,
+			"retryAfter": 2,
+			"rateLimit": {"limit": 100, "remaining": 0, "reset": 60}
+		}`))
+
+	})
+
+	It("ServiceUnavailable(r) should return a 503 Problem carrying r as extensions", func() {
+
+		// when
+		p := ServiceUnavailable(Retry{After: time.Minute})
+
+		// then
+		Expect(p.Status).To(Equal(503))
+		Expect(p.ExtensionKeys()).To(Equal([]string{RetryAfterExtensionKey}))
+
+	})
+
+	It("ServeHTTP should write the Retry-After and RateLimit header fields", func() {
+
+		// given
+		p := TooManyRequests(Retry{After: 30 * time.Second, RateLimit: rateLimit})
+		w := httptest.NewRecorder()
+
+		// when
+		p.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// then
+		Expect(w.Code).To(Equal(429))
+		Expect(w.Header().Get("Retry-After")).To(Equal("30"))
+		Expect(w.Header().Get("RateLimit-Limit")).To(Equal("100"))
+		Expect(w.Header().Get("RateLimit-Remaining")).To(Equal("0"))
+		Expect(w.Header().Get("RateLimit-Reset")).To(Equal("60"))
+
+	})
+
+	It("ServeHTTP should not write retry header fields for other Problems", func() {
+
+		// given
+		p := Problem{Status: 503}
+		w := httptest.NewRecorder()
+
+		// when
+		p.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// then
+		Expect(w.Header()).ToNot(HaveKey("Retry-After"))
+		Expect(w.Header()).ToNot(HaveKey("Ratelimit-Limit"))
+
+	})
+
+	It("RetryOf(p) should read the Retry from a decoded document", func() {
+
+		// given
+		p := Problem{}
+		p.UnmarshalJSON([]byte(`{"status": 429, "retryAfter": 30, "rateLimit": {"limit": 100, "remaining": 5, "reset": 60}}`))
+
+		// when
+		result, ok := RetryOf(p)
+
+		// then
+		Expect(ok).To(BeTrue())
+		Expect(result).To(Equal(Retry{
+			After:     30 * time.Second,
+			RateLimit: &RateLimit{Limit: 100, Remaining: 5, Reset: 60 * time.Second},
+		}))
+
+	})
+
+	It("RetryOf(p) should report a Problem without retry extensions", func() {
+
+		// when
+		_, ok := RetryOf(Problem{Status: 429})
+
+		// then
+		Expect(ok).To(BeFalse())
+
+	})
+
+	It("RetryOf(p) should ignore invalid retry extensions", func() {
+
+		// given
+		p := Problem{}
+		p.Extend(RetryAfterExtensionKey, "soon")
+		p.Extend(RateLimitExtensionKey, "none")
+
+		// when
+		_, ok := RetryOf(p)
+
+		// then
+		Expect(ok).To(BeFalse())
+
+	})
+
+	DescribeTable(
+		"ParseRetry(h)",
+		func(h http.Header, out Retry) {
+
+			// when
+			result, err := ParseRetry(h)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(out))
+
+		},
+		Entry("no header fields", http.Header{}, Retry{}),
+		Entry("delay-seconds", http.Header{"Retry-After": {"120"}}, Retry{After: 2 * time.Minute}),
+		Entry("past HTTP-date", http.Header{"Retry-After": {"Fri, 31 Dec 1999 23:59:59 GMT"}}, Retry{}),
+		Entry(
+			"RateLimit header fields",
+			http.Header{"Ratelimit-Limit": {"100"}, "Ratelimit-Remaining": {"0"}, "Ratelimit-Reset": {"60"}},
+			Retry{RateLimit: &RateLimit{Limit: 100, Reset: time.Minute}},
+		),
+		Entry(
+			"RateLimit-Limit with quota policies",
+			http.Header{"Ratelimit-Limit": {"100, 100;w=60, 1000;w=3600"}, "Ratelimit-Remaining": {"50"}},
+			Retry{RateLimit: &RateLimit{Limit: 100, Remaining: 50}},
+		),
+	)
+
+	It("ParseRetry(h) should compute the delay until a future HTTP-date", func() {
+
+		// given
+		date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+
+		// when
+		result, err := ParseRetry(http.Header{"Retry-After": {date}})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.After).To(BeNumerically("~", time.Hour, 2*time.Second))
+
+	})
+
+	DescribeTable(
+		"ParseRetry(h) should report invalid header fields",
+		func(h http.Header, expected error) {
+
+			// when
+			_, err := ParseRetry(h)
+
+			// then
+			Expect(err).To(Equal(expected))
+
+		},
+		Entry("Retry-After", http.Header{"Retry-After": {"soon"}}, ErrInvalidRetryAfter),
+		Entry("negative Retry-After", http.Header{"Retry-After": {"-1"}}, ErrInvalidRetryAfter),
+		Entry("RateLimit-Limit", http.Header{"Ratelimit-Limit": {"many"}}, ErrInvalidRateLimit),
+		Entry("RateLimit-Reset", http.Header{"Ratelimit-Limit": {"100"}, "Ratelimit-Reset": {"-5"}}, ErrInvalidRateLimit),
+	)
+
+})
@@ -0,0 +1,268 @@
+package rfc7807
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// SchemaDialect is the JSON Schema dialect of generated schemas, which is also the default dialect of OpenAPI 3.1
+	SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+	// BaseSchemaName is the name of the base Problem schema within OpenAPI components and the $defs of per-type schemas
+	BaseSchemaName = "Problem"
+)
+
+var (
+	// ErrInvalidSchemaName describes a ProblemType whose Name is not a valid OpenAPI component name
+	ErrInvalidSchemaName = errors.New("rfc7807: schema name must match ^[a-zA-Z0-9._-]+$ and must not be " + BaseSchemaName)
+
+	// ErrDuplicateProblemType describes an attempt to register a problem type, or schema name, more than once
+	ErrDuplicateProblemType = errors.New("rfc7807: problem type is already registered")
+
+	// ErrUnknownProblemType describes an attempt to generate the schema of a problem type that is not registered
+	ErrUnknownProblemType = errors.New("rfc7807: problem type is not registered")
+
+	componentName = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+)
+
+// ExtensionMember declares an extension member of a problem type
+type ExtensionMember struct {
+	Name        string
+	Description string
+	Required    bool
+
+	// Schema is the JSON Schema of the member's value, e.g. {"type": "integer"}. A nil Schema accepts any value.
+	Schema map[string]interface{}
+}
+
+// ProblemType declares a problem type, its recommended status, its extension members and its localized Messages. The
+// same ProblemType is registered with Schemas to document it and with a Catalog to localize it.
+type ProblemType struct {
+	// Name is the name of the type's schema within OpenAPI components, e.g. "OutOfCredit"
+	Name string
+
+	Type        string
+	Title       string
+	Status      int // the HTTP status code of the type, or 0 if any may be used
+	Description string
+	Extensions  []ExtensionMember
+
+	// Messages holds the localized title and detail of the type by language tag. See Catalog.RegisterType
+	Messages map[string]Messages
+}
+
+// Schemas generates JSON Schemas and OpenAPI components for registered problem types. Schemas is safe for concurrent
+// use.
+type Schemas struct {
+	// Catalog, when set, is given every problem type registered with these Schemas, so that the problem types that are
+	// documented and those that are localized are the same
+	Catalog *Catalog
+
+	mu    sync.RWMutex
+	types []ProblemType
+}
+
+// Register adds a problem type, and registers it with the Catalog when set. An empty Type is treated as
+// "about:blank". Extension members must not use reserved key names.
+func (s *Schemas) Register(t ProblemType) error {
+
+	if !componentName.MatchString(t.Name) || t.Name == BaseSchemaName {
+		return ErrInvalidSchemaName
+	}
+
+	for _, extension := range t.Extensions {
+
+		if _, reserved := ReservedKeys[strings.ToLower(extension.Name)]; reserved {
+			return ErrExtensionKeyIsReserved
+		}
+
+	}
+
+	t.Type = typeOrBlank(t.Type)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, registered := range s.types {
+
+		if registered.Type == t.Type || registered.Name == t.Name {
+			return ErrDuplicateProblemType
+		}
+
+	}
+
+	if s.Catalog != nil {
+
+		if err := s.Catalog.RegisterType(t); err != nil {
+			return err
+		}
+
+	}
+
+	s.types = append(s.types, t)
+
+	return nil
+
+}
+
+// Types returns every registered problem type, in the order registered
+func (s *Schemas) Types() []ProblemType {
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]ProblemType(nil), s.types...)
+
+}
+
+// BaseSchema returns the JSON Schema of the Problem Details object, adapted from RFC 9457 Appendix A
+func BaseSchema() map[string]interface{} {
+
+	return map[string]interface{}{
+		"type":        "object",
+		"description": "Problem Details for HTTP APIs (RFC 9457)",
+		"properties": map[string]interface{}{
+			"type": map[string]interface{}{
+				"type":        "string",
+				"format":      "uri-reference",
+				"description": "A URI reference that identifies the problem type.",
+				"default":     "about:blank",
+			},
+			"title": map[string]interface{}{
+				"type":        "string",
+				"description": "A short, human-readable summary of the problem type.",
+			},
+			"status": map[string]interface{}{
+				"type":        "integer",
+				"format":      "int32",
+				"description": "The HTTP status code generated by the origin server for this occurrence of the problem.",
+				"minimum":     100,
+				"maximum":     599,
+			},
+			"detail": map[string]interface{}{
+				"type":        "string",
+				"description": "A human-readable explanation specific to this occurrence of the problem.",
+			},
+			"instance": map[string]interface{}{
+				"type":        "string",
+				"format":      "uri-reference",
+				"description": "A URI reference that identifies the specific occurrence of the problem.",
+			},
+		},
+	}
+
+}
+
+// JSONSchema returns a standalone JSON Schema for the registered problem type, which embeds the base schema in $defs
+func (s *Schemas) JSONSchema(problemType string) (map[string]interface{}, error) {
+
+	problemType = typeOrBlank(problemType)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, t := range s.types {
+
+		if t.Type == problemType {
+
+			result := typeSchema(t, "#/$defs/"+BaseSchemaName)
+			result["$schema"] = SchemaDialect
+			result["$defs"] = map[string]interface{}{BaseSchemaName: BaseSchema()}
+
+			return result, nil
+
+		}
+
+	}
+
+	return nil, ErrUnknownProblemType
+
+}
+
+// Components returns an OpenAPI 3.1 document fragment holding the base schema and the schema of every registered
+// problem type under components.schemas, keyed by name
+func (s *Schemas) Components() map[string]interface{} {
+
+	schemas := map[string]interface{}{BaseSchemaName: BaseSchema()}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, t := range s.types {
+		schemas[t.Name] = typeSchema(t, "#/components/schemas/"+BaseSchemaName)
+	}
+
+	return map[string]interface{}{
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+
+}
+
+// typeSchema returns the schema of a problem type, which extends the base schema referenced by ref
+func typeSchema(t ProblemType, ref string) map[string]interface{} {
+
+	properties := map[string]interface{}{
+		"type": map[string]interface{}{"const": t.Type},
+	}
+
+	// an omitted type member means "about:blank"
+	required := []string{}
+	if t.Type != "about:blank" {
+		required = append(required, "type")
+	}
+
+	if t.Title != "" {
+		properties["title"] = map[string]interface{}{"examples": []string{t.Title}}
+	}
+
+	if t.Status != 0 {
+		properties["status"] = map[string]interface{}{"const": t.Status}
+	}
+
+	for _, extension := range t.Extensions {
+
+		schema := map[string]interface{}{}
+
+		for key, value := range extension.Schema {
+			schema[key] = value
+		}
+
+		if extension.Description != "" {
+			schema["description"] = extension.Description
+		}
+
+		properties[extension.Name] = schema
+
+		if extension.Required {
+			required = append(required, extension.Name)
+		}
+
+	}
+
+	result := map[string]interface{}{
+		"allOf": []interface{}{
+			map[string]interface{}{"$ref": ref},
+			map[string]interface{}{
+				"type":       "object",
+				"properties": properties,
+				"required":   required,
+			},
+		},
+	}
+
+	if t.Title != "" {
+		result["title"] = t.Title
+	}
+
+	if t.Description != "" {
+		result["description"] = t.Description
+	}
+
+	return result
+
+}
@@ -0,0 +1,254 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schemas", func() {
+
+	outOfCredit := ProblemType{
+		Name:        "OutOfCredit",
+		Type:        "https://example.com/probs/out-of-credit",
+		Title:       "You do not have enough credit.",
+		Status:      403,
+		Description: "The account balance does not cover the cost of the operation.",
+		Extensions: []ExtensionMember{
+			{Name: "balance", Required: true, Schema: map[string]interface{}{"type": "integer"}},
+			{Name: "accounts", Description: "Accounts that can be topped up.", Schema: map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "string", "format": "uri-reference"},
+			}},
+		},
+	}
+
+	typeSchemaJSON := func(ref string) string {
+		return `{
+			"title": "You do not have enough credit.",
+			"description": "The account balance does not cover the cost of the operation.",
+			"allOf": [
+				{"$ref": "` + ref + `"},
+				{
+					"type": "object",
+					"properties": {
+						"type": {"const": "https://example.com/probs/out-of-credit"},
+						"title": {"examples": ["You do not have enough credit."]},
+						"status": {"const": 403},
+						"balance": {"type": "integer"},
+						"accounts": {
+							"type": "array",
+							"items": {"type": "string", "format": "uri-reference"},
+							"description": "Accounts that can be topped up."
+						}
+					},
+					"required": ["type", "balance"]
+				}
+			]
+		}`
+	}
+
+	It("BaseSchema() should describe the standard members", func() {
+
+		// when
+		result, _ := json.Marshal(BaseSchema())
+
+		// then
+		Expect(result).To(MatchJSON(`{
+			"type": "object",
+			"description": "Problem Details for HTTP APIs (RFC 9457)",
+			"properties": {
+				"type": {
+					"type": "string",
+					"format": "uri-reference",
+					"description": "A URI reference that identifies the problem type.",
+					"default": "about:blank"
+				},
+				"title": {
+					"type": "string",
+					"description": "A short, human-readable summary of the problem type."
+				},
+				"status": {
+					"type": "integer",
+					"format": "int32",
+					"description": "The HTTP status code generated by the origin server for this occurrence of the problem.",
+					"minimum": 100,
+					"maximum": 599
+				},
+				"detail": {
+					"type": "string",
+					"description": "A human-readable explanation specific to this occurrence of the problem."
+				},
+				"instance": {
+					"type": "string",
+					"format": "uri-reference",
+					"description": "A URI reference that identifies the specific occurrence of the problem."
+				}
+			}
+		}`))
+
+	})
+
+	It("JSONSchema(problemType) should return a standalone schema embedding the base schema", func() {
+
+		// given
+		var s Schemas
+		Expect(s.Register(outOfCredit)).To(Succeed())
+
+		expected := map[string]interface{}{}
+		json.Unmarshal([]byte(typeSchemaJSON("#/$defs/Problem")), &expected)
+		expected["$schema"] = SchemaDialect
+		expected["$defs"] = map[string]interface{}{"Problem": BaseSchema()}
+		expectedJSON, _ := json.Marshal(expected)
+
+		// when
+		result, err := s.JSONSchema("https://example.com/probs/out-of-credit")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		resultJSON, _ := json.Marshal(result)
+		Expect(resultJSON).To(MatchJSON(expectedJSON))
+
+	})
+
+	It("JSONSchema(problemType) should return ErrUnknownProblemType for unregistered types", func() {
+
+		// given
+		var s Schemas
+
+		// when
+		_, err := s.JSONSchema("https://example.com/probs/unknown")
+
+		// then
+		Expect(err).To(Equal(ErrUnknownProblemType))
+
+	})
+
+	It("Components() should return the base schema and every registered type by name", func() {
+
+		// given
+		var s Schemas
+		Expect(s.Register(outOfCredit)).To(Succeed())
+		Expect(s.Register(ProblemType{Name: "Blank"})).To(Succeed())
+
+		// when
+		result, _ := json.Marshal(s.Components())
+
+		// then
+		base, _ := json.Marshal(BaseSchema())
+		Expect(result).To(MatchJSON(`{
+			"components": {
+				"schemas": {
+					"Problem": ` + string(base) + `,
+					"OutOfCredit": ` + typeSchemaJSON("#/components/schemas/Problem") + `,
+					"Blank": {
+						"allOf": [
+							{"$ref": "#/components/schemas/Problem"},
+							{
+								"type": "object",
+								"properties": {"type": {"const": "about:blank"}},
+								"required": []
+							}
+						]
+					}
+				}
+			}
+		}`))
+
+	})
+
+	Describe("Register(t)", func() {
+
+		It("should register the same ProblemType with the Catalog when set", func() {
+
+			// given
+			s := Schemas{Catalog: NewCatalog("en")}
+
+			t := outOfCredit
+			t.Messages = map[string]Messages{
+				"de": {Title: "Sie haben nicht genügend Guthaben.", Detail: "Ihr Guthaben beträgt {{.balance}}."},
+			}
+
+			p := Problem{Type: t.Type}
+			p.Extend("balance", 30)
+
+			// when
+			err := s.Register(t)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s.Types()).To(Equal([]ProblemType{t}))
+
+			result, language, ok := s.Catalog.Localize(p, "")
+			Expect(ok).To(BeTrue())
+			Expect(language).To(Equal("en"))
+			Expect(result.Title).To(Equal("You do not have enough credit."))
+
+			result, language, ok = s.Catalog.Localize(p, "de")
+			Expect(ok).To(BeTrue())
+			Expect(language).To(Equal("de"))
+			Expect(result.Detail).To(Equal("Ihr Guthaben beträgt 30."))
+
+		})
+
+		It("should register nothing if the Catalog rejects the ProblemType", func() {
+
+			// given
+			s := Schemas{Catalog: NewCatalog("en")}
+
+			t := outOfCredit
+			t.Messages = map[string]Messages{"de": {Detail: "{{.balance"}}
+
+			// when
+			err := s.Register(t)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(s.Types()).To(BeEmpty())
+
+			_, _, ok := s.Catalog.Localize(Problem{Type: t.Type}, "")
+			Expect(ok).To(BeFalse())
+
+		})
+
+		It("should return ErrInvalidSchemaName for invalid names", func() {
+
+			// given
+			var s Schemas
+
+			// expect
+			Expect(s.Register(ProblemType{Name: "", Type: "https://example.com/a"})).To(Equal(ErrInvalidSchemaName))
+			Expect(s.Register(ProblemType{Name: "Out Of Credit", Type: "https://example.com/a"})).To(Equal(ErrInvalidSchemaName))
+			Expect(s.Register(ProblemType{Name: "Problem", Type: "https://example.com/a"})).To(Equal(ErrInvalidSchemaName))
+
+		})
+
+		It("should return ErrExtensionKeyIsReserved for reserved extension names", func() {
+
+			// given
+			var s Schemas
+
+			// when
+			err := s.Register(ProblemType{Name: "A", Extensions: []ExtensionMember{{Name: "Detail"}}})
+
+			// then
+			Expect(err).To(Equal(ErrExtensionKeyIsReserved))
+
+		})
+
+		It("should return ErrDuplicateProblemType for duplicate types or names", func() {
+
+			// given
+			var s Schemas
+			Expect(s.Register(outOfCredit)).To(Succeed())
+
+			// expect
+			Expect(s.Register(ProblemType{Name: "Other", Type: outOfCredit.Type})).To(Equal(ErrDuplicateProblemType))
+			Expect(s.Register(ProblemType{Name: "OutOfCredit", Type: "https://example.com/a"})).To(Equal(ErrDuplicateProblemType))
+
+		})
+
+	})
+
+})
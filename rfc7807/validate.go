@@ -0,0 +1,264 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/tniswong/go.rfcx/internal/jsonobject"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Validation Errors
+var (
+	ErrInvalidTypeURI = errors.New("rfc7807: type must be a URI reference")
+	ErrStatusMismatch = errors.New("rfc7807: status must match the HTTP status code of the response")
+	ErrExtensionName  = errors.New("rfc7807: extension name should start with a letter and consist of at least three letters, digits or underscores")
+
+	extensionName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{2,}$`)
+)
+
+// ValidationError reports every violation found while validating a Problem or Problem Details document
+type ValidationError struct {
+	Violations []error
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	return joinErrors(e.Violations)
+}
+
+// Validate checks the Problem against the rules of RFC 7807: type must be a URI reference as defined by RFC 3986
+// Sec. 4.1, status, when present, must be between 100 and 599, and extension names should take the form recommended
+// by Sec. 3.2. InvalidParamsExtensionKey, which RFC 7807 Sec. 3 itself uses, is accepted despite its hyphen. A
+// *ValidationError listing every violation is returned when any are found.
+func (p Problem) Validate() error {
+
+	var violations []error
+
+	if !isURIReference(p.Type) {
+		violations = append(violations, &MemberError{Member: "type", Err: ErrInvalidTypeURI})
+	}
+
+	if p.Status != 0 && (p.Status < 100 || p.Status > 599) {
+		violations = append(violations, &MemberError{Member: "status", Err: ErrStatusOutOfRange})
+	}
+
+	for _, extensionKey := range p.extensionKeys {
+
+		if !isExtensionName(extensionKey) {
+			violations = append(violations, &MemberError{Member: extensionKey, Err: ErrExtensionName})
+		}
+
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+
+	return nil
+
+}
+
+// ValidateJSON checks the Problem Details document in data against the rules of RFC 7807, as Problem.Validate does,
+// additionally checking that each standard member holds a value of the correct type and that standard member names
+// are not used with a different case. When status is non-zero, it is the HTTP status code of the response carrying
+// the document, which the status member must match. Malformed JSON is reported as is; otherwise a *ValidationError
+// listing every violation is returned when any are found.
+func ValidateJSON(data []byte, status int) error {
+
+	in, err := jsonobject.Members(data)
+
+	if err != nil {
+		return err
+	}
+
+	var (
+		violations []error
+		rules      = memberRules{typeReference: true, statusRange: true, status: status, extensionNames: true}
+	)
+
+	for _, m := range in {
+
+		if _, err := checkMember(m.Name, m.Value, rules); err != nil {
+			violations = append(violations, err)
+		}
+
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+
+	return nil
+
+}
+
+// memberRules selects the checks checkMember applies beyond the types of the standard members
+type memberRules struct {
+	// typeReference requires type to be a URI reference
+	typeReference bool
+
+	// absoluteType requires type to be an absolute URI
+	absoluteType bool
+
+	// statusRange requires status to be between 100 and 599
+	statusRange bool
+
+	// status, when non-zero, is the HTTP status code the status member must match
+	status int
+
+	// extensionNames requires extension names to take the form recommended by RFC 7807 Sec. 3.2
+	extensionNames bool
+}
+
+// checkMember checks a single member of a Problem Details document, returning a violation if it does not conform.
+// Otherwise the value of a standard member is returned as the string, int or url.URL it is held as by Problem, and
+// nil is returned for an extension.
+func checkMember(k string, raw json.RawMessage, rules memberRules) (interface{}, error) {
+
+	switch k {
+	case "type":
+
+		var str string
+		if err := unmarshalString(raw, &str); err != nil {
+			return nil, typeError(k, raw, reflect.TypeOf(""))
+		}
+
+		if rules.absoluteType && !isAbsoluteURI(str) {
+			return nil, &MemberError{Member: k, Err: ErrRelativeTypeURI}
+		}
+
+		if rules.typeReference && !isURIReference(str) {
+			return nil, &MemberError{Member: k, Err: ErrInvalidTypeURI}
+		}
+
+		return str, nil
+
+	case "title", "detail":
+
+		var str string
+		if err := unmarshalString(raw, &str); err != nil {
+			return nil, typeError(k, raw, reflect.TypeOf(""))
+		}
+
+		return str, nil
+
+	case "status":
+
+		var num float64
+		if err := unmarshalNumber(raw, &num); err != nil || !isInt(num) {
+			return nil, typeError(k, raw, reflect.TypeOf(0))
+		}
+
+		if rules.statusRange && (num < 100 || num > 599) {
+			return nil, &MemberError{Member: k, Err: ErrStatusOutOfRange}
+		}
+
+		if rules.status != 0 && int(num) != rules.status {
+			return nil, &MemberError{Member: k, Err: ErrStatusMismatch}
+		}
+
+		return int(num), nil
+
+	case "instance":
+
+		var str string
+		if err := unmarshalString(raw, &str); err != nil {
+			return nil, typeError(k, raw, reflect.TypeOf(url.URL{}))
+		}
+
+		uri, err := url.Parse(str)
+
+		if err != nil || !isURIReference(str) {
+			return nil, &MemberError{Member: k, Err: ErrInvalidInstance}
+		}
+
+		return *uri, nil
+
+	default:
+
+		if _, reserved := ReservedKeys[strings.ToLower(k)]; reserved {
+			return nil, &MemberError{Member: k, Err: ErrMemberNameCase}
+		}
+
+		if rules.extensionNames && !isExtensionName(k) {
+			return nil, &MemberError{Member: k, Err: ErrExtensionName}
+		}
+
+		return nil, nil
+
+	}
+
+}
+
+// isExtensionName returns true if k takes the form of extension names recommended by RFC 7807 Sec. 3.2, or is
+// InvalidParamsExtensionKey
+func isExtensionName(k string) bool {
+	return extensionName.MatchString(k) || k == InvalidParamsExtensionKey
+}
+
+// isURIReference returns true if s is a URI-reference as defined by RFC 3986 Sec. 4.1: it may only contain the
+// characters of RFC 3986 Sec. 2, with percent signs beginning percent-encodings, and a single "#" separating the
+// fragment
+func isURIReference(s string) bool {
+
+	for x := 0; x < len(s); x++ {
+
+		c := s[x]
+
+		if c == '%' {
+
+			if x+2 >= len(s) || !isHexDigit(s[x+1]) || !isHexDigit(s[x+2]) {
+				return false
+			}
+
+			x += 2
+			continue
+
+		}
+
+		if !isURIChar(c) {
+			return false
+		}
+
+	}
+
+	if strings.Count(s, "#") > 1 {
+		return false
+	}
+
+	_, err := url.Parse(s)
+	return err == nil
+
+}
+
+// isAbsoluteURI returns true if s is a URI reference with a scheme
+func isAbsoluteURI(s string) bool {
+
+	if !isURIReference(s) {
+		return false
+	}
+
+	uri, err := url.Parse(s)
+	return err == nil && uri.IsAbs()
+
+}
+
+// isURIChar returns true if c is an unreserved or reserved character as defined by RFC 3986 Sec. 2.2 and 2.3
+func isURIChar(c byte) bool {
+
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+
+	return strings.IndexByte("-._~:/?#[]@!$&'()*+,;=", c) >= 0
+
+}
+
+// isHexDigit returns true if c is a hexadecimal digit
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
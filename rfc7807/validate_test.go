@@ -0,0 +1,191 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/tniswong/go.rfcx/rfc6901"
+	"reflect"
+)
+
+var _ = Describe("Validation", func() {
+
+	Describe("Validate()", func() {
+
+		It("should accept a conforming Problem", func() {
+
+			// given
+			p, _ := New(
+				WithType("https://example.com/probs/out-of-credit"),
+				WithTitle("You do not have enough credit."),
+				WithStatus(403),
+				WithInstance("/account/12345/msgs/abc"),
+				WithExtension("balance", 30),
+			)
+
+			// expect
+			Expect(p.Validate()).To(Succeed())
+
+		})
+
+		It("should accept the zero-value Problem", func() {
+			Expect(Problem{}.Validate()).To(Succeed())
+		})
+
+		It("should report every violation together", func() {
+
+			// given
+			p, _ := New(
+				WithType("%zz"),
+				WithStatus(42),
+				WithExtension("out-of-credit", []string{}),
+				WithExtension("ok", true),
+			)
+
+			// when
+			err := p.Validate()
+
+			// then
+			Expect(err).To(Equal(&ValidationError{
+				Violations: []error{
+					&MemberError{Member: "type", Err: ErrInvalidTypeURI},
+					&MemberError{Member: "status", Err: ErrStatusOutOfRange},
+					&MemberError{Member: "out-of-credit", Err: ErrExtensionName},
+					&MemberError{Member: "ok", Err: ErrExtensionName},
+				},
+			}))
+
+		})
+
+		DescribeTable(
+			"should report a type that is not a URI reference",
+			func(problemType string) {
+
+				// when
+				err := Problem{Type: problemType}.Validate()
+
+				// then
+				Expect(err).To(Equal(&ValidationError{
+					Violations: []error{&MemberError{Member: "type", Err: ErrInvalidTypeURI}},
+				}))
+
+			},
+			Entry("spaces", "this is not a uri"),
+			Entry("disallowed characters", "<<not a uri>>"),
+			Entry("malformed percent-encoding", "/probs/%zz"),
+			Entry("several fragments", "/probs#a#b"),
+		)
+
+		It("should accept the InvalidParams Problem", func() {
+
+			// given
+			var ip InvalidParams
+			ip.Add(rfc6901.NewPointer("age"), "must be a positive integer")
+
+			p, _ := ip.Problem(InvalidParamsExtensionKey)
+
+			// expect
+			Expect(p.Validate()).To(Succeed())
+
+		})
+
+	})
+
+	Describe("ValidateJSON(data, status)", func() {
+
+		It("should accept a conforming document", func() {
+
+			// when
+			err := ValidateJSON([]byte(`{
+                "type": "/probs/out-of-credit",
+                "title": "You do not have enough credit.",
+                "status": 403,
+                "detail": "Your current balance is 30, but that costs 50.",
+                "instance": "/account/12345/msgs/abc",
+                "balance": 30
+            }`), 403)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+		})
+
+		It("should report every violation together, in document order", func() {
+
+			// when
+			err := ValidateJSON([]byte(`{
+                "TYPE": "https://example.com/probs/out-of-credit",
+                "title": 42,
+                "detail": null,
+                "instance": "Not a valid url !@#$%^&*()_+",
+                "status": 404,
+                "type": "%zz",
+                "1st": true
+            }`), 403)
+
+			// then
+			Expect(err).To(Equal(&ValidationError{
+				Violations: []error{
+					&MemberError{Member: "TYPE", Err: ErrMemberNameCase},
+					&json.UnmarshalTypeError{Value: "number 42", Type: reflect.TypeOf(""), Field: "title", Struct: "Problem"},
+					&json.UnmarshalTypeError{Value: "null", Type: reflect.TypeOf(""), Field: "detail", Struct: "Problem"},
+					&MemberError{Member: "instance", Err: ErrInvalidInstance},
+					&MemberError{Member: "status", Err: ErrStatusMismatch},
+					&MemberError{Member: "type", Err: ErrInvalidTypeURI},
+					&MemberError{Member: "1st", Err: ErrExtensionName},
+				},
+			}))
+			Expect(err.Error()).To(ContainSubstring("; "))
+
+		})
+
+		DescribeTable(
+			"should report type and instance members that are not URI references",
+			func(uri string) {
+
+				// when
+				err := ValidateJSON([]byte(`{"type": "`+uri+`", "instance": "`+uri+`"}`), 0)
+
+				// then
+				Expect(err).To(Equal(&ValidationError{
+					Violations: []error{
+						&MemberError{Member: "type", Err: ErrInvalidTypeURI},
+						&MemberError{Member: "instance", Err: ErrInvalidInstance},
+					},
+				}))
+
+			},
+			Entry("spaces", "this is not a uri"),
+			Entry("disallowed characters", "<<not a uri>>"),
+		)
+
+		It("should report a status out of range", func() {
+
+			// when
+			err := ValidateJSON([]byte(`{"status": 600}`), 0)
+
+			// then
+			Expect(err).To(Equal(&ValidationError{
+				Violations: []error{&MemberError{Member: "status", Err: ErrStatusOutOfRange}},
+			}))
+
+		})
+
+		It("should not compare the status member when status is 0", func() {
+			Expect(ValidateJSON([]byte(`{"status": 404}`), 0)).To(Succeed())
+		})
+
+		It("should return the error describing malformed JSON", func() {
+
+			// when
+			err := ValidateJSON([]byte(`{"status": `), 0)
+
+			// then
+			Expect(err).To(BeAssignableToTypeOf(&json.SyntaxError{}))
+
+		})
+
+	})
+
+})
@@ -0,0 +1,86 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/tniswong/go.rfcx/rfc6901"
+	"net/http"
+)
+
+const (
+	// InvalidParamsExtensionKey is the extension key used for validation failures by the example in RFC 7807 Sec. 3
+	InvalidParamsExtensionKey = "invalid-params"
+
+	// ErrorsExtensionKey is the extension key used for validation failures by the example in RFC 9457 Sec. 3
+	ErrorsExtensionKey = "errors"
+)
+
+var (
+	// ErrNoInvalidParams describes an attempt to read InvalidParams from a Problem that does not carry them
+	ErrNoInvalidParams = errors.New("rfc7807: the problem does not carry invalid params under the given extension key")
+)
+
+// InvalidParam describes a single validation failure. Pointer locates the offending field within the request
+// document, and Value optionally holds the rejected value.
+type InvalidParam struct {
+	Pointer rfc6901.Pointer `json:"pointer"`
+	Reason  string          `json:"reason"`
+	Value   interface{}     `json:"value,omitempty"`
+}
+
+// InvalidParams is a list of validation failures which can be reported together as a single Problem
+type InvalidParams []InvalidParam
+
+// Add appends a validation failure for the field located by pointer
+func (ip *InvalidParams) Add(pointer rfc6901.Pointer, reason string) {
+	*ip = append(*ip, InvalidParam{Pointer: pointer, Reason: reason})
+}
+
+// AddValue appends a validation failure for the field located by pointer, including the rejected value
+func (ip *InvalidParams) AddValue(pointer rfc6901.Pointer, reason string, value interface{}) {
+	*ip = append(*ip, InvalidParam{Pointer: pointer, Reason: reason, Value: value})
+}
+
+// Problem returns a 400 "about:blank" Problem carrying the validation failures as an extension under key, which is
+// usually InvalidParamsExtensionKey or ErrorsExtensionKey
+func (ip InvalidParams) Problem(key string) (Problem, error) {
+
+	p := blank(http.StatusBadRequest)
+
+	if err := p.Extend(key, ip); err != nil {
+		return Problem{}, err
+	}
+
+	return p, nil
+
+}
+
+// ParseInvalidParams reads the validation failures carried by p under key, whether p was built by
+// InvalidParams.Problem or decoded from JSON
+func ParseInvalidParams(p Problem, key string) (InvalidParams, error) {
+
+	value, ok := p.Extension(key)
+
+	if !ok {
+		return nil, ErrNoInvalidParams
+	}
+
+	if ip, ok := value.(InvalidParams); ok {
+		return ip, nil
+	}
+
+	data, err := json.Marshal(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result InvalidParams
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
@@ -0,0 +1,101 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tniswong/go.rfcx/rfc6901"
+)
+
+var _ = Describe("InvalidParams", func() {
+
+	params := func() InvalidParams {
+
+		var ip InvalidParams
+		ip.Add(rfc6901.NewPointer("age"), "must be a positive integer")
+		ip.AddValue(rfc6901.NewPointer("colors", "a/b~c").AppendIndex(0), "must be 'green', 'red' or 'blue'", "purple")
+
+		return ip
+
+	}
+
+	It("should build a 400 Problem carrying the invalid params", func() {
+
+		// when
+		p, err := params().Problem(InvalidParamsExtensionKey)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(p.Status).To(Equal(400))
+		Expect(p.Type).To(Equal("about:blank"))
+		Expect(p.Title).To(Equal("Bad Request"))
+
+		data, err := json.Marshal(p)
+		Expect(err).To(BeNil())
+		Expect(data).To(MatchJSON(`{
+            "type": "about:blank",
+            "title": "Bad Request",
+            "status": 400,
+            "invalid-params": [
+                {"pointer": "/age", "reason": "must be a positive integer"},
+                {"pointer": "/colors/a~1b~0c/0", "reason": "must be 'green', 'red' or 'blue'", "value": "purple"}
+            ]
+        }`))
+
+	})
+
+	It("should return ErrExtensionKeyIsReserved given a reserved key", func() {
+
+		// when
+		_, err := params().Problem("detail")
+
+		// then
+		Expect(err).To(Equal(ErrExtensionKeyIsReserved))
+
+	})
+
+	It("should read the invalid params back from a decoded Problem", func() {
+
+		// given
+		p, _ := params().Problem(ErrorsExtensionKey)
+		data, _ := json.Marshal(p)
+		decoded := Problem{}
+		Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+
+		// when
+		result, err := ParseInvalidParams(decoded, ErrorsExtensionKey)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(params()))
+
+	})
+
+	It("should return ErrNoInvalidParams if the Problem does not carry them", func() {
+
+		// when
+		_, err := ParseInvalidParams(Problem{}, InvalidParamsExtensionKey)
+
+		// then
+		Expect(err).To(Equal(ErrNoInvalidParams))
+
+	})
+
+	It("should marshal as XML", func() {
+
+		// given
+		p, _ := params().Problem(InvalidParamsExtensionKey)
+
+		// when
+		data, err := xml.Marshal(p)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(ContainSubstring(
+			`<invalid-params><i><pointer>/age</pointer><reason>must be a positive integer</reason></i>`,
+		))
+
+	})
+
+})
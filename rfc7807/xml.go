@@ -0,0 +1,312 @@
+package rfc7807
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// XMLMediaType is the MIME Media type for the XML representation of the Problem struct
+	XMLMediaType = "application/problem+xml"
+
+	// XMLNamespace is the XML namespace of the problem element as defined by RFC 7807 Appendix A
+	XMLNamespace = "urn:ietf:rfc:7807"
+)
+
+// MarshalXML Marshals XML as described by RFC 7807 Appendix A. Array extension values are encoded as repeated "i"
+// elements, object extension values as nested elements, and Problem extension values as nested elements with their
+// members in order. Sensitive extensions are omitted.
+func (p Problem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return p.encodeXMLElement(e, xml.StartElement{Name: xml.Name{Space: XMLNamespace, Local: "problem"}})
+}
+
+// encodeXMLElement encodes the members of p, standard members first, as the children of the element given by start
+func (p Problem) encodeXMLElement(e *xml.Encoder, start xml.StartElement) error {
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if p.Type != "" {
+		if err := encodeXMLValue(e, "type", p.Type); err != nil {
+			return err
+		}
+	}
+
+	if p.Title != "" {
+		if err := encodeXMLValue(e, "title", p.Title); err != nil {
+			return err
+		}
+	}
+
+	if p.Status != 0 {
+		if err := encodeXMLValue(e, "status", p.Status); err != nil {
+			return err
+		}
+	}
+
+	if p.Detail != "" {
+		if err := encodeXMLValue(e, "detail", p.Detail); err != nil {
+			return err
+		}
+	}
+
+	var zero url.URL
+	if p.Instance != zero {
+		if err := encodeXMLValue(e, "instance", p.Instance.String()); err != nil {
+			return err
+		}
+	}
+
+	for _, extensionKey := range p.extensionKeys {
+
+		if isSensitive(extensionKey) {
+			continue
+		}
+
+		if err := encodeXMLValue(e, extensionKey, p.extensions[extensionKey]); err != nil {
+			return err
+		}
+
+	}
+
+	return e.EncodeToken(start.End())
+
+}
+
+// encodeXMLValue encodes value as an element with the given name
+func encodeXMLValue(e *xml.Encoder, name string, value interface{}) error {
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	v := reflect.ValueOf(value)
+
+	// a Problem keeps the order of its members, which its generic value would not
+	if p, ok := value.(Problem); ok {
+		return p.encodeXMLElement(e, start)
+	}
+
+	// structs, pointers and values with their own JSON representation are encoded as the generic value of that
+	// representation
+	if _, ok := value.(json.Marshaler); ok || v.Kind() == reflect.Struct || v.Kind() == reflect.Ptr {
+
+		generic, err := jsonGeneric(value)
+
+		if err != nil {
+			return err
+		}
+
+		return encodeXMLValue(e, name, generic)
+
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+
+		for x := 0; x < v.Len(); x++ {
+			if err := encodeXMLValue(e, "i", v.Index(x).Interface()); err != nil {
+				return err
+			}
+		}
+
+		return e.EncodeToken(start.End())
+
+	case reflect.Map:
+
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+
+		for _, key := range keys {
+			if err := encodeXMLValue(e, fmt.Sprint(key.Interface()), v.MapIndex(key).Interface()); err != nil {
+				return err
+			}
+		}
+
+		return e.EncodeToken(start.End())
+
+	case reflect.Invalid:
+		return e.EncodeElement("", start)
+
+	default:
+		return e.EncodeElement(fmt.Sprint(value), start)
+	}
+
+}
+
+// jsonGeneric converts value to the generic value encoding/json would decode from its JSON representation
+func jsonGeneric(value interface{}) (interface{}, error) {
+
+	data, err := json.Marshal(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+	err = json.Unmarshal(data, &result)
+
+	return result, err
+
+}
+
+// UnmarshalXML unmarshalls XML as described by RFC 7807 Appendix A. Extension values are decoded as strings, as a
+// []interface{} when every child element is named "i", or as a map[string]interface{} otherwise.
+func (p *Problem) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+
+	for {
+
+		token, err := d.Token()
+
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+
+			value, err := decodeXMLValue(d)
+
+			if err != nil {
+				return err
+			}
+
+			if err := p.xmlMember(t.Name.Local, value); err != nil {
+				return err
+			}
+
+		case xml.EndElement:
+			return nil
+		}
+
+	}
+
+}
+
+// xmlMember assigns a decoded XML element to the corresponding member of p
+func (p *Problem) xmlMember(name string, value interface{}) error {
+
+	if _, reserved := ReservedKeys[name]; !reserved {
+		return p.Extend(name, value)
+	}
+
+	str, ok := value.(string)
+
+	if !ok {
+		return xml.UnmarshalError(fmt.Sprintf("rfc7807: %s must contain character data only", name))
+	}
+
+	switch name {
+	case "type":
+		p.Type = str
+	case "title":
+		p.Title = str
+	case "status":
+
+		status, err := strconv.Atoi(strings.TrimSpace(str))
+
+		if err != nil {
+			return xml.UnmarshalError(fmt.Sprintf("rfc7807: status must be an integer, got %q", str))
+		}
+
+		p.Status = status
+
+	case "detail":
+		p.Detail = str
+	case "instance":
+
+		uri, err := url.Parse(strings.TrimSpace(str))
+
+		if err != nil {
+			return err
+		}
+
+		p.Instance = *uri
+
+	}
+
+	return nil
+
+}
+
+// decodeXMLValue decodes the remainder of the current element, up to and including its end element
+func decodeXMLValue(d *xml.Decoder) (interface{}, error) {
+
+	var (
+		text     strings.Builder
+		names    []string
+		children []interface{}
+	)
+
+	for {
+
+		token, err := d.Token()
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.CharData:
+			text.Write(t)
+		case xml.StartElement:
+
+			child, err := decodeXMLValue(d)
+
+			if err != nil {
+				return nil, err
+			}
+
+			names = append(names, t.Name.Local)
+			children = append(children, child)
+
+		case xml.EndElement:
+
+			if len(children) == 0 {
+				return text.String(), nil
+			}
+
+			return xmlContainer(names, children), nil
+
+		}
+
+	}
+
+}
+
+// xmlContainer builds a []interface{} when every child is named "i", otherwise a map[string]interface{}
+func xmlContainer(names []string, children []interface{}) interface{} {
+
+	for _, name := range names {
+
+		if name != "i" {
+
+			result := map[string]interface{}{}
+
+			for x, name := range names {
+				result[name] = children[x]
+			}
+
+			return result
+
+		}
+
+	}
+
+	return children
+
+}
@@ -0,0 +1,119 @@
+package rfc7807
+
+import (
+	"encoding/xml"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("XML", func() {
+
+	Describe("MarshalXML", func() {
+
+		It("should marshal standard members and extensions in the RFC 7807 namespace", func() {
+
+			// given
+			p := Problem{
+				Type:     "https://example.com/probs/out-of-credit",
+				Title:    "You do not have enough credit.",
+				Status:   403,
+				Detail:   "Your current balance is 30, but that costs 50.",
+				Instance: URL("/account/12345/msgs/abc"),
+			}
+			p.Extend("balance", 30)
+			p.Extend("accounts", []string{"/account/12345", "/account/67890"})
+
+			// when
+			result, err := xml.Marshal(p)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(string(result)).To(Equal(
+				`<problem xmlns="urn:ietf:rfc:7807">` +
+					`<type>https://example.com/probs/out-of-credit</type>` +
+					`<title>You do not have enough credit.</title>` +
+					`<status>403</status>` +
+					`<detail>Your current balance is 30, but that costs 50.</detail>` +
+					`<instance>/account/12345/msgs/abc</instance>` +
+					`<balance>30</balance>` +
+					`<accounts><i>/account/12345</i><i>/account/67890</i></accounts>` +
+					`</problem>`,
+			))
+
+		})
+
+		It("should marshal object extensions as nested elements", func() {
+
+			// given
+			p := Problem{}
+			p.Extend("limits", map[string]interface{}{"remaining": 0, "limit": 10})
+
+			// when
+			result, err := xml.Marshal(p)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(string(result)).To(Equal(
+				`<problem xmlns="urn:ietf:rfc:7807"><limits><limit>10</limit><remaining>0</remaining></limits></problem>`,
+			))
+
+		})
+
+	})
+
+	Describe("UnmarshalXML", func() {
+
+		It("should unmarshal standard members and extensions", func() {
+
+			// given
+			in := `<?xml version="1.0" encoding="UTF-8"?>
+                <problem xmlns="urn:ietf:rfc:7807">
+                    <type>https://example.com/probs/out-of-credit</type>
+                    <title>You do not have enough credit.</title>
+                    <status>403</status>
+                    <detail>Your current balance is 30, but that costs 50.</detail>
+                    <instance>/account/12345/msgs/abc</instance>
+                    <balance>30</balance>
+                    <accounts>
+                        <i>/account/12345</i>
+                        <i>/account/67890</i>
+                    </accounts>
+                </problem>`
+			result := Problem{}
+
+			// when
+			err := xml.Unmarshal([]byte(in), &result)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result.Type).To(Equal("https://example.com/probs/out-of-credit"))
+			Expect(result.Title).To(Equal("You do not have enough credit."))
+			Expect(result.Status).To(Equal(403))
+			Expect(result.Detail).To(Equal("Your current balance is 30, but that costs 50."))
+			Expect(result.Instance).To(Equal(URL("/account/12345/msgs/abc")))
+			Expect(result.ExtensionKeys()).To(Equal([]string{"balance", "accounts"}))
+
+			balance, _ := result.Extension("balance")
+			Expect(balance).To(Equal("30"))
+
+			accounts, _ := result.Extension("accounts")
+			Expect(accounts).To(Equal([]interface{}{"/account/12345", "/account/67890"}))
+
+		})
+
+		It("should return an error describing a non-integer status", func() {
+
+			// given
+			result := Problem{}
+
+			// when
+			err := xml.Unmarshal([]byte(`<problem xmlns="urn:ietf:rfc:7807"><status>abc</status></problem>`), &result)
+
+			// then
+			Expect(err).To(Equal(xml.UnmarshalError(`rfc7807: status must be an integer, got "abc"`)))
+
+		})
+
+	})
+
+})
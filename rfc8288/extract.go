@@ -0,0 +1,393 @@
+package rfc8288
+
+import (
+	"encoding/xml"
+	"html"
+	"io"
+	"net/url"
+	"strings"
+)
+
+const (
+	// AtomNamespace is the XML namespace of Atom documents as defined by RFC4287
+	AtomNamespace = "http://www.w3.org/2005/Atom"
+
+	// xmlNamespace is the namespace bound to the xml prefix, as in xml:base
+	xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+)
+
+// rawTextElements holds the HTML elements whose content is text rather than markup
+var rawTextElements = map[string]struct{}{
+	"script":   {},
+	"style":    {},
+	"textarea": {},
+	"title":    {},
+}
+
+// ExtractHTML returns the links of an HTML document, i.e. its <link> elements and the <a> and <area> elements with a
+// rel attribute, in document order. Targets are resolved against the document's base URL, given by its first <base>
+// element with an href, resolved against documentURL, which may be nil. Links are given the rel, type, hreflang,
+// media and title attributes of their elements.
+func ExtractHTML(r io.Reader, documentURL *url.URL) (Links, error) {
+
+	data, err := io.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		doc     = string(data)
+		base    = documentURL
+		baseSet bool
+		tags    []map[string]string
+	)
+
+	for x := 0; x < len(doc); {
+
+		start := strings.IndexByte(doc[x:], '<')
+
+		if start < 0 {
+			break
+		}
+
+		x += start
+
+		switch {
+		case strings.HasPrefix(doc[x:], "<!--"):
+			x = skipPast(doc, x+len("<!--"), "-->")
+			continue
+		case strings.HasPrefix(doc[x:], "<!"), strings.HasPrefix(doc[x:], "<?"), strings.HasPrefix(doc[x:], "</"):
+			x = skipPast(doc, x, ">")
+			continue
+		}
+
+		name, attrs, end := htmlTag(doc, x)
+
+		if name == "" {
+			x++
+			continue
+		}
+
+		x = end
+
+		switch name {
+		case "base":
+
+			if href, ok := attrs["href"]; ok && !baseSet {
+
+				if uri, err := url.Parse(strings.TrimSpace(href)); err == nil {
+					base = resolve(base, uri)
+					baseSet = true
+				}
+
+			}
+
+		case "link":
+			tags = append(tags, attrs)
+		case "a", "area":
+
+			if _, ok := attrs["rel"]; ok {
+				tags = append(tags, attrs)
+			}
+
+		}
+
+		// the content of raw text elements may look like markup
+		if _, ok := rawTextElements[name]; ok && x < len(doc) {
+
+			closing := strings.Index(strings.ToLower(doc[x:]), "</"+name)
+
+			if closing < 0 {
+				break
+			}
+
+			x += closing
+
+		}
+
+	}
+
+	var result Links
+
+	for _, attrs := range tags {
+
+		href, ok := attrs["href"]
+
+		if !ok {
+			continue
+		}
+
+		uri, err := url.Parse(strings.TrimSpace(href))
+
+		if err != nil {
+			continue
+		}
+
+		result = append(result, Link{
+			HREF:     *resolve(base, uri),
+			Rel:      attrs["rel"],
+			HREFLang: attrs["hreflang"],
+			Media:    attrs["media"],
+			Title:    attrs["title"],
+			Type:     attrs["type"],
+		})
+
+	}
+
+	return result, nil
+
+}
+
+// resolve returns uri resolved against base, or uri itself when base is nil
+func resolve(base *url.URL, uri *url.URL) *url.URL {
+
+	if base == nil {
+		return uri
+	}
+
+	return base.ResolveReference(uri)
+
+}
+
+// skipPast returns the index following the first occurrence of s in doc at or after x, or len(doc) if there is none
+func skipPast(doc string, x int, s string) int {
+
+	end := strings.Index(doc[x:], s)
+
+	if end < 0 {
+		return len(doc)
+	}
+
+	return x + end + len(s)
+
+}
+
+// htmlTag parses the start tag at doc[x], returning its lowercased name, attributes and the index following it. The
+// name is empty if doc[x] does not begin a start tag. Attribute names are lowercased and values unescaped, and the
+// first of repeated attributes is kept.
+func htmlTag(doc string, x int) (string, map[string]string, int) {
+
+	x++
+	start := x
+
+	for x < len(doc) && isASCIILetterOrDigit(doc[x]) {
+		x++
+	}
+
+	if x == start || !isASCIILetter(doc[start]) {
+		return "", nil, x
+	}
+
+	var (
+		name  = strings.ToLower(doc[start:x])
+		attrs = map[string]string{}
+	)
+
+	for x < len(doc) {
+
+		// skip whitespace and self-closing slashes between attributes
+		for x < len(doc) && (isHTMLSpace(doc[x]) || doc[x] == '/') {
+			x++
+		}
+
+		if x >= len(doc) || doc[x] == '>' {
+			break
+		}
+
+		var key, value string
+
+		key, x = htmlAttrName(doc, x)
+		value, x = htmlAttrValue(doc, x)
+
+		if _, ok := attrs[key]; !ok && key != "" {
+			attrs[key] = html.UnescapeString(value)
+		}
+
+	}
+
+	if x < len(doc) {
+		x++
+	}
+
+	return name, attrs, x
+
+}
+
+// htmlAttrName scans the attribute name at doc[x], returning it lowercased and the index following it
+func htmlAttrName(doc string, x int) (string, int) {
+
+	start := x
+
+	for x < len(doc) && !isHTMLSpace(doc[x]) && doc[x] != '=' && doc[x] != '>' && doc[x] != '/' {
+		x++
+	}
+
+	return strings.ToLower(doc[start:x]), x
+
+}
+
+// htmlAttrValue scans the optional value following an attribute name at doc[x], i.e. an equals sign and a quoted or
+// unquoted value, returning it and the index following it. The value is empty if there is none.
+func htmlAttrValue(doc string, x int) (string, int) {
+
+	x = skipHTMLSpace(doc, x)
+
+	if x >= len(doc) || doc[x] != '=' {
+		return "", x
+	}
+
+	x = skipHTMLSpace(doc, x+1)
+
+	if x < len(doc) && (doc[x] == '"' || doc[x] == '\'') {
+
+		end := strings.IndexByte(doc[x+1:], doc[x])
+
+		// an unterminated value runs to the end of the document
+		if end < 0 {
+			return doc[x+1:], len(doc)
+		}
+
+		return doc[x+1 : x+1+end], x + end + 2
+
+	}
+
+	start := x
+
+	for x < len(doc) && !isHTMLSpace(doc[x]) && doc[x] != '>' {
+		x++
+	}
+
+	return doc[start:x], x
+
+}
+
+// skipHTMLSpace returns the index of the first character at or after doc[x] that is not HTML whitespace
+func skipHTMLSpace(doc string, x int) int {
+
+	for x < len(doc) && isHTMLSpace(doc[x]) {
+		x++
+	}
+
+	return x
+
+}
+
+// isHTMLSpace returns true if c is ASCII whitespace as defined by HTML
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
+
+// isASCIILetter returns true if c is an ASCII letter
+func isASCIILetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// isASCIILetterOrDigit returns true if c is an ASCII letter or digit
+func isASCIILetterOrDigit(c byte) bool {
+	return isASCIILetter(c) || '0' <= c && c <= '9'
+}
+
+// ExtractAtom returns the links of an Atom feed or entry document, i.e. its atom:link elements, including those
+// embedded in other documents such as RSS feeds, in document order. Targets are resolved against the xml:base in
+// scope, resolved against documentURL, which may be nil. As described by RFC4287 Sec. 4.2.7.2, a link without a rel
+// attribute has the "alternate" relation type. The length attribute is kept as an extension.
+func ExtractAtom(r io.Reader, documentURL *url.URL) (Links, error) {
+
+	var (
+		d      = xml.NewDecoder(r)
+		bases  = []*url.URL{documentURL}
+		result Links
+	)
+
+	for {
+
+		t, err := d.Token()
+
+		if err == io.EOF {
+			return result, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch element := t.(type) {
+		case xml.StartElement:
+
+			base := bases[len(bases)-1]
+
+			for _, attr := range element.Attr {
+
+				if attr.Name.Local == "base" && (attr.Name.Space == xmlNamespace || attr.Name.Space == "xml") {
+
+					if uri, err := url.Parse(attr.Value); err == nil {
+						base = resolve(base, uri)
+					}
+
+				}
+
+			}
+
+			bases = append(bases, base)
+
+			if element.Name.Space != AtomNamespace || element.Name.Local != "link" {
+				continue
+			}
+
+			if l, ok := atomLink(element, base); ok {
+				result = append(result, l)
+			}
+
+		case xml.EndElement:
+			bases = bases[:len(bases)-1]
+		}
+
+	}
+
+}
+
+// atomLink returns the Link described by an atom:link element, resolving its target against base. A bool is also
+// returned to signify whether the element has a valid href.
+func atomLink(element xml.StartElement, base *url.URL) (Link, bool) {
+
+	var (
+		l       = Link{Rel: RelAlternate}
+		hasHREF bool
+	)
+
+	for _, attr := range element.Attr {
+
+		if attr.Name.Space != "" {
+			continue
+		}
+
+		switch attr.Name.Local {
+		case "href":
+
+			uri, err := url.Parse(strings.TrimSpace(attr.Value))
+
+			if err != nil {
+				return Link{}, false
+			}
+
+			l.HREF = *resolve(base, uri)
+			hasHREF = true
+
+		case "rel":
+			l.Rel = attr.Value
+		case "type":
+			l.Type = attr.Value
+		case "hreflang":
+			l.HREFLang = attr.Value
+		case "title":
+			l.Title = attr.Value
+		case "length":
+			l.Extend("length", attr.Value)
+		}
+
+	}
+
+	return l, hasHREF
+
+}
@@ -0,0 +1,142 @@
+package rfc8288
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"strings"
+)
+
+var _ = Describe("Extract", func() {
+
+	documentURL := URL("https://example.com/articles/1")
+
+	It("ExtractHTML(r, documentURL) should return the links of an HTML document, resolved against its base", func() {
+
+		// given
+		doc := `<!DOCTYPE html>
+			<HTML>
+			<head>
+				<title>A <link href="/not-a-link"> title</title>
+				<link rel="stylesheet" href="style.css" media="print">
+				<base href="/static/">
+				<base href="/ignored/">
+				<LINK REL=alternate HREF='/de' hreflang=de type="text/html" title="Deutsch &amp; mehr">
+				<!-- <link rel="next" href="/commented"> -->
+				<script>document.write('<link rel="preload" href="/scripted">')</script>
+			</head>
+			<body>
+				<a href="/plain">not a link relation</a>
+				<a rel="next nofollow" href="https://example.com/articles/2">Next</a>
+				<area rel="help" href="help.html" />
+				<link rel="icon">
+			</body>
+			</HTML>`
+
+		// when
+		result, err := ExtractHTML(strings.NewReader(doc), &documentURL)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(Links{
+			{HREF: URL("https://example.com/static/style.css"), Rel: "stylesheet", Media: "print"},
+			{HREF: URL("https://example.com/de"), Rel: "alternate", HREFLang: "de", Type: "text/html", Title: "Deutsch & mehr"},
+			{HREF: URL("https://example.com/articles/2"), Rel: "next nofollow"},
+			{HREF: URL("https://example.com/static/help.html"), Rel: "help"},
+		}))
+
+	})
+
+	It("ExtractHTML(r, nil) should leave targets relative to a missing document URL", func() {
+
+		// when
+		result, err := ExtractHTML(strings.NewReader(`<link rel=next href=page2><p>text < more`), nil)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(Links{{HREF: URL("page2"), Rel: "next"}}))
+
+	})
+
+	DescribeTable(
+		"ExtractHTML(r, documentURL) should not fail on unterminated quoted attribute values",
+		func(doc string, out Links) {
+
+			// when
+			result, err := ExtractHTML(strings.NewReader(doc), nil)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(out))
+
+		},
+		Entry("in a script", `<script src='a`, Links(nil)),
+		Entry("in a title", `<html><head><title x="unterminated`, Links(nil)),
+		Entry("in a link", `<link rel="next" href="/page2`, Links{{HREF: URL("/page2"), Rel: "next"}}),
+	)
+
+	It("ExtractAtom(r, documentURL) should return the links of an Atom feed, resolved against xml:base", func() {
+
+		// given
+		doc := `<?xml version="1.0" encoding="utf-8"?>
+			<feed xmlns="http://www.w3.org/2005/Atom">
+				<title>Example Feed</title>
+				<link href="/feed" rel="self"/>
+				<link href="http://example.org/"/>
+				<entry xml:base="https://example.org/2003/">
+					<title>Atom-Powered Robots Run Amok</title>
+					<link rel="enclosure" type="audio/mpeg" length="1337" href="12/13/robots.mp3"/>
+					<link rel="related" hreflang="en" title="Robots" href="https://example.net/robots"/>
+				</entry>
+				<link rel="next" href="?page=2"/>
+			</feed>`
+
+		// when
+		result, err := ExtractAtom(strings.NewReader(doc), &documentURL)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		enclosure := Link{HREF: URL("https://example.org/2003/12/13/robots.mp3"), Rel: "enclosure", Type: "audio/mpeg"}
+		enclosure.Extend("length", "1337")
+
+		Expect(result).To(Equal(Links{
+			{HREF: URL("https://example.com/feed"), Rel: "self"},
+			{HREF: URL("http://example.org/"), Rel: "alternate"},
+			enclosure,
+			{HREF: URL("https://example.net/robots"), Rel: "related", HREFLang: "en", Title: "Robots"},
+			{HREF: URL("https://example.com/articles/1?page=2"), Rel: "next"},
+		}))
+
+	})
+
+	It("ExtractAtom(r, documentURL) should return atom:link elements embedded in RSS feeds", func() {
+
+		// given
+		doc := `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">
+				<channel>
+					<link>https://example.com/</link>
+					<atom:link href="https://example.com/rss" rel="self" type="application/rss+xml"/>
+				</channel>
+			</rss>`
+
+		// when
+		result, err := ExtractAtom(strings.NewReader(doc), nil)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(Links{{HREF: URL("https://example.com/rss"), Rel: "self", Type: "application/rss+xml"}}))
+
+	})
+
+	It("ExtractAtom(r, documentURL) should return XML syntax errors", func() {
+
+		// when
+		_, err := ExtractAtom(strings.NewReader(`<feed xmlns="http://www.w3.org/2005/Atom"><link href="/">`), nil)
+
+		// then
+		Expect(err).To(HaveOccurred())
+
+	})
+
+})
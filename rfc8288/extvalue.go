@@ -0,0 +1,196 @@
+package rfc8288
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidExtValue describes a parameter value that is not an ext-value as defined by RFC8187 Sec. 3.2
+var ErrInvalidExtValue = errors.New("rfc8288: invalid ext-value")
+
+// ExtValue is the value of an extended parameter, such as title*, as defined by RFC8187. It allows characters outside
+// of US-ASCII, and an optional language tag, to be carried in header fields.
+type ExtValue struct {
+	// Charset is either "UTF-8" or "ISO-8859-1", compared case-insensitively. An empty Charset is "UTF-8".
+	Charset  string
+	Language string
+
+	// Value is the decoded text
+	Value string
+}
+
+// ParseExtValue attempts to parse an ext-value of the form charset'language'value-chars, percent-decoding the value
+func ParseExtValue(s string) (ExtValue, error) {
+
+	parts := strings.SplitN(s, "'", 3)
+
+	if len(parts) != 3 {
+		return ExtValue{}, ErrInvalidExtValue
+	}
+
+	charset, language, encoded := parts[0], parts[1], parts[2]
+
+	var decoded []byte
+
+	for x := 0; x < len(encoded); x++ {
+
+		c := encoded[x]
+
+		if c == '%' {
+
+			if x+2 >= len(encoded) || !isHex(encoded[x+1]) || !isHex(encoded[x+2]) {
+				return ExtValue{}, ErrInvalidExtValue
+			}
+
+			decoded = append(decoded, unhex(encoded[x+1])<<4|unhex(encoded[x+2]))
+			x += 2
+			continue
+
+		}
+
+		if !isAttrChar(c) {
+			return ExtValue{}, ErrInvalidExtValue
+		}
+
+		decoded = append(decoded, c)
+
+	}
+
+	switch {
+	case strings.EqualFold(charset, "UTF-8"):
+
+		if !utf8.Valid(decoded) {
+			return ExtValue{}, ErrInvalidExtValue
+		}
+
+		return ExtValue{Charset: charset, Language: language, Value: string(decoded)}, nil
+
+	case strings.EqualFold(charset, "ISO-8859-1"):
+
+		// every ISO-8859-1 byte is the code point of the same value
+		runes := make([]rune, len(decoded))
+
+		for x, b := range decoded {
+			runes[x] = rune(b)
+		}
+
+		return ExtValue{Charset: charset, Language: language, Value: string(runes)}, nil
+
+	default:
+		return ExtValue{}, ErrInvalidExtValue
+	}
+
+}
+
+// String returns the ExtValue as an ext-value, percent-encoding the value. The value is encoded as UTF-8 unless
+// Charset is "ISO-8859-1" and every character of the value can be represented in it. An ext-value is never quoted.
+func (e ExtValue) String() string {
+
+	var (
+		charset = "UTF-8"
+		encoded = []byte(e.Value)
+	)
+
+	if strings.EqualFold(e.Charset, "ISO-8859-1") {
+
+		if latin1, ok := toLatin1(e.Value); ok {
+			charset = e.Charset
+			encoded = latin1
+		}
+
+	}
+
+	var b strings.Builder
+
+	b.WriteString(charset)
+	b.WriteByte('\'')
+	b.WriteString(e.Language)
+	b.WriteByte('\'')
+
+	const digits = "0123456789ABCDEF"
+
+	for _, c := range encoded {
+
+		if isAttrChar(c) {
+			b.WriteByte(c)
+		} else {
+			b.WriteByte('%')
+			b.WriteByte(digits[c>>4])
+			b.WriteByte(digits[c&0x0F])
+		}
+
+	}
+
+	return b.String()
+
+}
+
+// MarshalText marshals the ExtValue as an ext-value
+func (e ExtValue) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText unmarshals an ext-value
+func (e *ExtValue) UnmarshalText(text []byte) error {
+
+	value, err := ParseExtValue(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	*e = value
+	return nil
+
+}
+
+// toLatin1 returns s encoded as ISO-8859-1. A bool is also returned to signify whether every rune could be encoded.
+func toLatin1(s string) ([]byte, bool) {
+
+	var result []byte
+
+	for _, r := range s {
+
+		if r > 0xFF {
+			return nil, false
+		}
+
+		result = append(result, byte(r))
+
+	}
+
+	return result, true
+
+}
+
+// isAttrChar returns true if c is an attr-char as defined by RFC8187 Sec. 3.2.1
+func isAttrChar(c byte) bool {
+
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+
+	return strings.IndexByte("!#$&+-.^_`|~", c) >= 0
+
+}
+
+// isHex returns true if c is a hexadecimal digit
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// unhex returns the value of the hexadecimal digit c
+func unhex(c byte) byte {
+
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+
+}
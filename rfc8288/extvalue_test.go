@@ -0,0 +1,101 @@
+package rfc8288
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExtValue", func() {
+
+	DescribeTable(
+		"ParseExtValue(s)",
+		func(in string, out ExtValue) {
+
+			// when
+			result, err := ParseExtValue(in)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(out))
+
+		},
+		Entry("UTF-8", "UTF-8'en'%E2%82%AC%20rates", ExtValue{Charset: "UTF-8", Language: "en", Value: "€ rates"}),
+		Entry("lowercase charset", "utf-8''%c2%a3", ExtValue{Charset: "utf-8", Value: "£"}),
+		Entry("ISO-8859-1", "ISO-8859-1'de'%A3%20rates", ExtValue{Charset: "ISO-8859-1", Language: "de", Value: "£ rates"}),
+		Entry("attr-chars", "UTF-8''!#$&+-.^_`|~", ExtValue{Charset: "UTF-8", Value: "!#$&+-.^_`|~"}),
+		Entry("empty value", "UTF-8'en'", ExtValue{Charset: "UTF-8", Language: "en"}),
+	)
+
+	DescribeTable(
+		"ParseExtValue(s) should return ErrInvalidExtValue",
+		func(in string) {
+
+			// when
+			_, err := ParseExtValue(in)
+
+			// then
+			Expect(err).To(Equal(ErrInvalidExtValue))
+
+		},
+		Entry("missing language", "UTF-8'value"),
+		Entry("unsupported charset", "UTF-16''value"),
+		Entry("missing charset", "'en'value"),
+		Entry("truncated percent-encoding", "UTF-8''%E"),
+		Entry("invalid percent-encoding", "UTF-8''%G0"),
+		Entry("invalid UTF-8", "UTF-8''%E2%82"),
+		Entry("character that must be encoded", "UTF-8''a b"),
+	)
+
+	DescribeTable(
+		"String()",
+		func(in ExtValue, out string) {
+
+			// when
+			result := in.String()
+
+			// then
+			Expect(result).To(Equal(out))
+
+		},
+		Entry("UTF-8", ExtValue{Charset: "UTF-8", Language: "en", Value: "€ rates"}, "UTF-8'en'%E2%82%AC%20rates"),
+		Entry("no charset", ExtValue{Value: "café"}, "UTF-8''caf%C3%A9"),
+		Entry("ISO-8859-1", ExtValue{Charset: "ISO-8859-1", Value: "£ rates"}, "ISO-8859-1''%A3%20rates"),
+		Entry("ISO-8859-1 unable to represent the value", ExtValue{Charset: "ISO-8859-1", Value: "€"}, "UTF-8''%E2%82%AC"),
+		Entry("quotes", ExtValue{Value: `"a"`}, "UTF-8''%22a%22"),
+	)
+
+	It("ParseExtValue(String()) should round trip", func() {
+
+		// given
+		in := ExtValue{Charset: "UTF-8", Language: "ja", Value: "こんにちは; 世界, \"quoted\""}
+
+		// when
+		result, err := ParseExtValue(in.String())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(in))
+
+	})
+
+	It("should marshal and unmarshal as a JSON string", func() {
+
+		// given
+		in := map[string]ExtValue{"title*": {Charset: "UTF-8", Language: "en", Value: "€"}}
+
+		// when
+		data, err := json.Marshal(in)
+
+		var result map[string]ExtValue
+		json.Unmarshal(data, &result)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"title*": "UTF-8'en'%E2%82%AC"}`))
+		Expect(result).To(Equal(in))
+
+	})
+
+})
@@ -17,6 +17,7 @@ var (
 	// ReservedKeys holds the names of all the reserved key names that are not allowed to be used as extensions
 	ReservedKeys = map[string]struct{}{
 		"href":     {},
+		"anchor":   {},
 		"rel":      {},
 		"hreflang": {},
 		"media":    {},
@@ -41,12 +42,17 @@ func ParseLink(link string) (Link, error) {
 
 // Link is an implementation of the structure defined by RFC8288 Web Linking
 type Link struct {
-	HREF      url.URL
+	HREF url.URL
+
+	// Anchor overrides the context of the link, which is otherwise the URI of the representation carrying it, as
+	// described by RFC8288 Sec. 3.2
+	Anchor url.URL
+
 	Rel       string
 	HREFLang  string
 	Media     string
 	Title     string
-	TitleStar string
+	TitleStar ExtValue
 	Type      string
 
 	extensionKeys []string
@@ -61,37 +67,228 @@ func (l Link) String() string {
 	result = append(result, fmt.Sprintf(`<%s>`, l.HREF.String()))
 
 	if l.Rel != "" {
-		result = append(result, fmt.Sprintf(`rel="%s"`, l.Rel))
+		result = append(result, fmt.Sprintf(`rel=%s`, quote(l.Rel)))
+	}
+
+	var zero url.URL
+	if l.Anchor != zero {
+		result = append(result, fmt.Sprintf(`anchor=%s`, quote(l.Anchor.String())))
 	}
 
 	if l.HREFLang != "" {
-		result = append(result, fmt.Sprintf(`hreflang="%s"`, l.HREFLang))
+		result = append(result, fmt.Sprintf(`hreflang=%s`, quote(l.HREFLang)))
 	}
 
 	if l.Media != "" {
-		result = append(result, fmt.Sprintf(`media="%s"`, l.Media))
+		result = append(result, fmt.Sprintf(`media=%s`, quote(l.Media)))
 	}
 
 	if l.Title != "" {
-		result = append(result, fmt.Sprintf(`title="%s"`, l.Title))
+		result = append(result, fmt.Sprintf(`title=%s`, quote(l.Title)))
 	}
 
-	if l.TitleStar != "" {
-		result = append(result, fmt.Sprintf(`title*="%s"`, l.TitleStar))
+	if l.TitleStar != (ExtValue{}) {
+		result = append(result, fmt.Sprintf(`title*=%s`, l.TitleStar))
 	}
 
 	if l.Type != "" {
-		result = append(result, fmt.Sprintf(`type="%s"`, l.Type))
+		result = append(result, fmt.Sprintf(`type=%s`, quote(l.Type)))
 	}
 
-	for key, value := range l.extensions {
-		result = append(result, fmt.Sprintf(`%s="%s"`, key, value))
+	for _, key := range l.extensionKeys {
+		result = append(result, extensionParam(key, l.extensions[key]))
 	}
 
 	return strings.Join(result, "; ")
 
 }
 
+// extensionParam formats an extension as a link-param. A value of true is formatted as a parameter without a value,
+// an ExtValue as an ext-value, a []string as a repeated parameter, and any other value as a token when safe to do so,
+// or otherwise a quoted-string.
+func extensionParam(key string, value interface{}) string {
+
+	switch v := value.(type) {
+	case bool:
+
+		if v {
+			return key
+		}
+
+	case ExtValue:
+		return fmt.Sprintf(`%s=%s`, key, v)
+	case []string:
+
+		// a parameter with several values is repeated
+		var params []string
+
+		for _, value := range v {
+			params = append(params, extensionParam(key, value))
+		}
+
+		return strings.Join(params, "; ")
+
+	}
+
+	str := fmt.Sprint(value)
+
+	if isToken(str) {
+		return fmt.Sprintf(`%s=%s`, key, str)
+	}
+
+	return fmt.Sprintf(`%s=%s`, key, quote(str))
+
+}
+
+// quote returns s as a quoted-string, escaping quotes and backslashes as quoted-pairs
+func quote(s string) string {
+
+	var b strings.Builder
+
+	b.WriteByte('"')
+
+	for _, r := range s {
+
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+
+		b.WriteRune(r)
+
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+
+}
+
+// isToken returns whether s is a non-empty token as defined by RFC7230 Sec. 3.2.6. A "*" is excluded, as it would
+// be read as marking an extended parameter.
+func isToken(s string) bool {
+
+	if s == "" {
+		return false
+	}
+
+	for x := 0; x < len(s); x++ {
+
+		c := s[x]
+
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.IndexByte("!#$%&'+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+
+	}
+
+	return true
+
+}
+
+// DisplayTitle returns the title of the Link, preferring the value of title* over title when both are present as
+// described by RFC8288 Sec. 3.4.1
+func (l Link) DisplayTitle() string {
+
+	if l.TitleStar != (ExtValue{}) {
+		return l.TitleStar.Value
+	}
+
+	return l.Title
+
+}
+
+// Rels returns the relation types of the Link, which may hold several separated by whitespace as described by RFC8288
+// Sec. 3.3. Relation types are lowercased, as they are compared case-insensitively, and duplicates are removed.
+func (l Link) Rels() []string {
+
+	var (
+		result []string
+		seen   = map[string]struct{}{}
+	)
+
+	for _, rel := range strings.Fields(l.Rel) {
+
+		rel = strings.ToLower(rel)
+
+		if _, ok := seen[rel]; !ok {
+			seen[rel] = struct{}{}
+			result = append(result, rel)
+		}
+
+	}
+
+	return result
+
+}
+
+// HasRel returns whether the Link has the given relation type, compared case-insensitively
+func (l Link) HasRel(rel string) bool {
+
+	for _, r := range strings.Fields(l.Rel) {
+
+		if strings.EqualFold(r, rel) {
+			return true
+		}
+
+	}
+
+	return false
+
+}
+
+// AddRel adds a relation type to the Link, unless it already has it
+func (l *Link) AddRel(rel string) {
+
+	if rel == "" || l.HasRel(rel) {
+		return
+	}
+
+	l.Rel = strings.Join(append(strings.Fields(l.Rel), rel), " ")
+
+}
+
+// RemoveRel removes a relation type from the Link, compared case-insensitively
+func (l *Link) RemoveRel(rel string) {
+
+	var result []string
+
+	for _, r := range strings.Fields(l.Rel) {
+
+		if !strings.EqualFold(r, rel) {
+			result = append(result, r)
+		}
+
+	}
+
+	l.Rel = strings.Join(result, " ")
+
+}
+
+// Resolve returns a copy of the Link whose target and context are absolute URIs, as described by RFC8288 Sec. 3.1 and
+// 3.2. HREF and Anchor are resolved against base, the URI of the representation carrying the link, which is also
+// the context when Anchor is unset.
+func (l Link) Resolve(base *url.URL) Link {
+
+	if base == nil {
+		base = &url.URL{}
+	}
+
+	l.HREF = *base.ResolveReference(&l.HREF)
+
+	var zero url.URL
+	if l.Anchor == zero {
+		l.Anchor = *base
+	} else {
+		l.Anchor = *base.ResolveReference(&l.Anchor)
+	}
+
+	return l
+
+}
+
 // ExtensionKeys returns a slice of strings representing the names of extension keys for this Link struct in the order
 // they were added
 func (l Link) ExtensionKeys() []string {
@@ -157,6 +354,10 @@ func (l Link) MarshalJSON() ([]byte, error) {
 		out["href"] = l.HREF.String()
 	}
 
+	if l.Anchor != zero {
+		out["anchor"] = l.Anchor.String()
+	}
+
 	if l.Rel != "" {
 		out["rel"] = l.Rel
 	}
@@ -173,7 +374,7 @@ func (l Link) MarshalJSON() ([]byte, error) {
 		out["title"] = l.Title
 	}
 
-	if l.TitleStar != "" {
+	if l.TitleStar != (ExtValue{}) {
 		out["title*"] = l.TitleStar
 	}
 
@@ -226,6 +427,32 @@ func (l *Link) UnmarshalJSON(data []byte) error {
 
 			}
 
+		case "anchor":
+
+			str, ok := v.(string)
+
+			if !ok {
+				return &json.UnmarshalTypeError{
+					Value:  "uri",
+					Type:   reflect.TypeOf(""),
+					Field:  "anchor",
+					Struct: "Link",
+				}
+			}
+
+			uri, err := url.Parse(str)
+
+			if err != nil {
+				return &json.UnmarshalTypeError{
+					Value:  "uri",
+					Type:   reflect.TypeOf(l.Anchor),
+					Field:  "anchor",
+					Struct: "Link",
+				}
+			}
+
+			l.Anchor = *uri
+
 		case "rel":
 
 			if str, ok := v.(string); ok {
@@ -280,9 +507,9 @@ func (l *Link) UnmarshalJSON(data []byte) error {
 
 		case "title*":
 
-			if str, ok := v.(string); ok {
-				l.TitleStar = str
-			} else {
+			str, ok := v.(string)
+
+			if !ok {
 				return &json.UnmarshalTypeError{
 					Value:  "string",
 					Type:   reflect.TypeOf(""),
@@ -291,6 +518,19 @@ func (l *Link) UnmarshalJSON(data []byte) error {
 				}
 			}
 
+			ext, err := ParseExtValue(str)
+
+			if err != nil {
+				return &json.UnmarshalTypeError{
+					Value:  "ext-value",
+					Type:   reflect.TypeOf(l.TitleStar),
+					Field:  "title*",
+					Struct: "Link",
+				}
+			}
+
+			l.TitleStar = ext
+
 		case "type":
 
 			if str, ok := v.(string); ok {
@@ -306,6 +546,15 @@ func (l *Link) UnmarshalJSON(data []byte) error {
 
 		default:
 
+			// the values of extended parameters are ext-values, when valid
+			if str, ok := v.(string); ok && strings.HasSuffix(k, "*") {
+
+				if ext, err := ParseExtValue(str); err == nil {
+					v = ext
+				}
+
+			}
+
 			if err := l.Extend(k, v); err != nil {
 
 				t := reflect.TypeOf(v)
@@ -31,6 +31,15 @@ var _ = Describe("Rfc8288", func() {
 				},
 				"<https://www.google.com>",
 			),
+			Entry(
+				"with href, rel, anchor",
+				Link{
+					HREF:   URL("https://www.google.com"),
+					Anchor: URL("#section"),
+					Rel:    "next",
+				},
+				`<https://www.google.com>; rel="next"; anchor="#section"`,
+			),
 			Entry(
 				"with href, hreflang",
 				Link{
@@ -67,9 +76,9 @@ var _ = Describe("Rfc8288", func() {
 				"with href, title*",
 				Link{
 					HREF:      URL("https://www.google.com"),
-					TitleStar: "title*",
+					TitleStar: ExtValue{Charset: "UTF-8", Language: "en", Value: "€ rates"},
 				},
-				`<https://www.google.com>; title*="title*"`,
+				`<https://www.google.com>; title*=UTF-8'en'%E2%82%AC%20rates`,
 			),
 			Entry(
 				"with href, type",
@@ -91,7 +100,78 @@ var _ = Describe("Rfc8288", func() {
 
 					return w
 				}(),
-				`<https://www.google.com>; extension="value"`,
+				`<https://www.google.com>; extension=value`,
+			),
+			Entry(
+				"with href, extensions in the order they were added",
+				func() Link {
+
+					w := Link{
+						HREF: URL("https://www.google.com"),
+					}
+
+					w.Extend("z", "1")
+					w.Extend("a", "2")
+					w.Extend("m", "3")
+
+					return w
+				}(),
+				`<https://www.google.com>; z=1; a=2; m=3`,
+			),
+			Entry(
+				"with href, extensions requiring quoting",
+				func() Link {
+
+					w := Link{
+						HREF: URL("https://www.google.com"),
+					}
+
+					w.Extend("space", "a b")
+					w.Extend("escaped", `say "hi" \o/`)
+					w.Extend("empty", "")
+					w.Extend("star", "a*b")
+
+					return w
+				}(),
+				`<https://www.google.com>; space="a b"; escaped="say \"hi\" \\o/"; empty=""; star="a*b"`,
+			),
+			Entry(
+				"with href, extensions without a value",
+				func() Link {
+
+					w := Link{
+						HREF: URL("https://www.google.com"),
+					}
+
+					w.Extend("crossorigin", true)
+					w.Extend("hidden", false)
+					w.Extend("count", 3)
+
+					return w
+				}(),
+				`<https://www.google.com>; crossorigin; hidden=false; count=3`,
+			),
+			Entry(
+				"with href, title requiring escaping",
+				Link{
+					HREF:  URL("https://www.google.com"),
+					Title: `say "hi" \o/`,
+				},
+				`<https://www.google.com>; title="say \"hi\" \\o/"`,
+			),
+			Entry(
+				"with href, extended extension",
+				func() Link {
+
+					w := Link{
+						HREF: URL("https://www.google.com"),
+					}
+
+					w.Extend("description*", ExtValue{Value: "café"})
+
+					return w
+				}(),
+				`<https://www.google.com>; description*=UTF-8''caf%C3%A9`,
 			),
 		)
 
@@ -114,6 +194,10 @@ var _ = Describe("Rfc8288", func() {
 					Expect(result["href"]).To(Equal(out["href"]))
 				}
 
+				if in.Anchor != zero {
+					Expect(result["anchor"]).To(Equal(out["anchor"]))
+				}
+
 				if in.Rel != "" {
 					Expect(result["rel"]).To(Equal(out["rel"]))
 				}
@@ -130,7 +214,7 @@ var _ = Describe("Rfc8288", func() {
 					Expect(result["title"]).To(Equal(out["title"]))
 				}
 
-				if in.TitleStar != "" {
+				if in.TitleStar != (ExtValue{}) {
 					Expect(result["title*"]).To(Equal(out["title*"]))
 				}
 
@@ -149,11 +233,12 @@ var _ = Describe("Rfc8288", func() {
 
 					l := Link{
 						HREF:      URL("https://www.google.com"),
+						Anchor:    URL("#anchor"),
 						Rel:       "rel",
 						HREFLang:  "hreflang",
 						Media:     "media",
 						Title:     "title",
-						TitleStar: "title*",
+						TitleStar: ExtValue{Charset: "UTF-8", Language: "en", Value: "€ rates"},
 						Type:      "type",
 					}
 
@@ -164,11 +249,12 @@ var _ = Describe("Rfc8288", func() {
 				}(),
 				map[string]interface{}{
 					"href":      "https://www.google.com",
+					"anchor":    "#anchor",
 					"rel":       "rel",
 					"hreflang":  "hreflang",
 					"media":     "media",
 					"title":     "title",
-					"title*":    "title*",
+					"title*":    "UTF-8'en'%E2%82%AC%20rates",
 					"type":      "type",
 					"extension": "value",
 				},
@@ -192,6 +278,10 @@ var _ = Describe("Rfc8288", func() {
 					Expect(result.HREF.String()).To(Equal(out.HREF.String()))
 				}
 
+				if _, ok := in["anchor"]; ok {
+					Expect(result.Anchor.String()).To(Equal(out.Anchor.String()))
+				}
+
 				if _, ok := in["rel"]; ok {
 					Expect(result.Rel).To(Equal(out.Rel))
 				}
@@ -237,11 +327,12 @@ var _ = Describe("Rfc8288", func() {
 				"should unmarshal all fields and extensions",
 				map[string]interface{}{
 					"href":      "https://www.google.com",
+					"anchor":    "#anchor",
 					"rel":       "rel",
 					"hreflang":  "hreflang",
 					"media":     "media",
 					"title":     "title",
-					"title*":    "title*",
+					"title*":    "UTF-8'en'%E2%82%AC%20rates",
 					"type":      "type",
 					"extension": "value",
 				},
@@ -249,11 +340,12 @@ var _ = Describe("Rfc8288", func() {
 
 					l := Link{
 						HREF:      URL("https://www.google.com"),
+						Anchor:    URL("#anchor"),
 						Rel:       "rel",
 						HREFLang:  "hreflang",
 						Media:     "media",
 						Title:     "title",
-						TitleStar: "title*",
+						TitleStar: ExtValue{Charset: "UTF-8", Language: "en", Value: "€ rates"},
 						Type:      "type",
 					}
 
@@ -303,6 +395,30 @@ var _ = Describe("Rfc8288", func() {
 					Struct: "Link",
 				},
 			),
+			Entry(
+				"should return json.UnmarshalTypeError describing anchor field",
+				`{
+                    "anchor": "Not a valid url !@#$%^&*()_+"
+                }`,
+				&json.UnmarshalTypeError{
+					Value:  "uri",
+					Type:   reflect.TypeOf(url.URL{}),
+					Field:  "anchor",
+					Struct: "Link",
+				},
+			),
+			Entry(
+				"should return json.UnmarshalTypeError describing anchor field",
+				`{
+                    "anchor": false
+                }`,
+				&json.UnmarshalTypeError{
+					Value:  "uri",
+					Type:   reflect.TypeOf(""),
+					Field:  "anchor",
+					Struct: "Link",
+				},
+			),
 			Entry(
 				"should return json.UnmarshalTypeError describing rel field",
 				`{
@@ -363,6 +479,18 @@ var _ = Describe("Rfc8288", func() {
 					Struct: "Link",
 				},
 			),
+			Entry(
+				"should return json.UnmarshalTypeError describing title* field",
+				`{
+                    "title*": "title*"
+                }`,
+				&json.UnmarshalTypeError{
+					Value:  "ext-value",
+					Type:   reflect.TypeOf(ExtValue{}),
+					Field:  "title*",
+					Struct: "Link",
+				},
+			),
 			Entry(
 				"should return json.UnmarshalTypeError describing type field",
 				`{
@@ -377,6 +505,162 @@ var _ = Describe("Rfc8288", func() {
 			),
 		)
 
+		It("ParseLink(String()) should round trip extensions", func() {
+
+			// given
+			in := Link{HREF: URL("https://www.google.com"), Rel: "next", Title: `say "hi" \o/`}
+			in.Extend("crossorigin", true)
+			in.Extend("token", "value")
+			in.Extend("quoted", `a; b, "c" \d`)
+			in.Extend("described*", ExtValue{Charset: "UTF-8", Value: "café"})
+
+			// when
+			result, err := ParseLink(in.String())
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(in))
+			Expect(result.String()).To(Equal(in.String()))
+
+		})
+
+		DescribeTable(
+			"DisplayTitle()",
+			func(in Link, out string) {
+
+				// when
+				result := in.DisplayTitle()
+
+				// then
+				Expect(result).To(Equal(out))
+
+			},
+			Entry("no title", Link{}, ""),
+			Entry("title", Link{Title: "Rates"}, "Rates"),
+			Entry("title*", Link{TitleStar: ExtValue{Value: "€ rates"}}, "€ rates"),
+			Entry("title and title*", Link{Title: "EUR rates", TitleStar: ExtValue{Value: "€ rates"}}, "€ rates"),
+		)
+
+		Describe("Resolve(base)", func() {
+
+			base := URL("https://example.com/articles/1?page=2")
+
+			It("should resolve the target and anchor against the base URI", func() {
+
+				// given
+				l := Link{HREF: URL("../2"), Anchor: URL("#comments"), Rel: "next"}
+
+				// when
+				result := l.Resolve(&base)
+
+				// then
+				Expect(result.HREF).To(Equal(URL("https://example.com/2")))
+				Expect(result.Anchor).To(Equal(URL("https://example.com/articles/1?page=2#comments")))
+				Expect(result.Rel).To(Equal("next"))
+				Expect(l.HREF).To(Equal(URL("../2")))
+
+			})
+
+			It("should use the base URI as the context when there is no anchor", func() {
+
+				// given
+				l := Link{HREF: URL("https://other.example/")}
+
+				// when
+				result := l.Resolve(&base)
+
+				// then
+				Expect(result.HREF).To(Equal(URL("https://other.example/")))
+				Expect(result.Anchor).To(Equal(base))
+
+			})
+
+			It("should leave references unresolved without a base URI", func() {
+
+				// given
+				l := Link{HREF: URL("/a"), Anchor: URL("#b")}
+
+				// when
+				result := l.Resolve(nil)
+
+				// then
+				Expect(result.HREF).To(Equal(URL("/a")))
+				Expect(result.Anchor).To(Equal(URL("#b")))
+
+			})
+
+		})
+
+		Describe("relation types", func() {
+
+			It("Rels() should return the normalized set of relation types", func() {
+
+				// given
+				l := Link{Rel: " next  Prefetch\tNEXT https://example.com/rel/Custom "}
+
+				// when
+				result := l.Rels()
+
+				// then
+				Expect(result).To(Equal([]string{"next", "prefetch", "https://example.com/rel/custom"}))
+
+			})
+
+			It("Rels() should return nothing without a rel", func() {
+				Expect(Link{}.Rels()).To(BeEmpty())
+			})
+
+			It("HasRel(rel) should compare relation types case-insensitively", func() {
+
+				// given
+				l := Link{Rel: "next prefetch"}
+
+				// expect
+				Expect(l.HasRel("next")).To(BeTrue())
+				Expect(l.HasRel("PREFETCH")).To(BeTrue())
+				Expect(l.HasRel("prev")).To(BeFalse())
+				Expect(l.HasRel("next prefetch")).To(BeFalse())
+
+			})
+
+			It("AddRel(rel) should add relation types once", func() {
+
+				// given
+				l := Link{}
+
+				// when
+				l.AddRel("next")
+				l.AddRel("prefetch")
+				l.AddRel("Next")
+				l.AddRel("")
+
+				// then
+				Expect(l.Rel).To(Equal("next prefetch"))
+				Expect(l.String()).To(Equal(`<>; rel="next prefetch"`))
+
+			})
+
+			It("RemoveRel(rel) should remove every occurrence of the relation type", func() {
+
+				// given
+				l := Link{Rel: "next prefetch NEXT"}
+
+				// when
+				l.RemoveRel("next")
+
+				// then
+				Expect(l.Rel).To(Equal("prefetch"))
+
+				// when
+				l.RemoveRel("prefetch")
+
+				// then
+				Expect(l.Rel).To(BeEmpty())
+
+			})
+
+		})
+
 		Describe("Extend(key, value)", func() {
 
 			It("should make the value accessible via Extension(key)", func() {
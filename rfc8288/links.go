@@ -0,0 +1,143 @@
+package rfc8288
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Links is an ordered collection of Link values, such as those carried by a Link header field
+type Links []Link
+
+// ParseLinks attempts to parse a Link header field value, a comma-separated list of links, returning every link in
+// order. Commas within quoted strings and URI references do not separate links.
+func ParseLinks(header string) (Links, error) {
+
+	var (
+		rs io.RuneScanner = strings.NewReader(header)
+		s                 = scanner{runeScanner: rs}
+		p                 = parser{scanner: s}
+	)
+
+	return p.links()
+
+}
+
+// ParseHeader attempts to parse every Link header field line of h, returning every link in order
+func ParseHeader(h http.Header) (Links, error) {
+
+	var result Links
+
+	for _, value := range h.Values("Link") {
+
+		links, err := ParseLinks(value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, links...)
+
+	}
+
+	return result, nil
+
+}
+
+// Filter returns the links for which keep returns true, in order
+func (ls Links) Filter(keep func(Link) bool) Links {
+
+	var result Links
+
+	for _, l := range ls {
+
+		if keep(l) {
+			result = append(result, l)
+		}
+
+	}
+
+	return result
+
+}
+
+// ByRel returns the links having the given relation type, in order. See Link.HasRel
+func (ls Links) ByRel(rel string) Links {
+	return ls.Filter(func(l Link) bool {
+		return l.HasRel(rel)
+	})
+}
+
+// First returns the first link having the given relation type. A bool is also returned to signify whether one was
+// found.
+func (ls Links) First(rel string) (Link, bool) {
+
+	for _, l := range ls {
+
+		if l.HasRel(rel) {
+			return l, true
+		}
+
+	}
+
+	return Link{}, false
+
+}
+
+// ByType returns the links with the given type hint, in order. Media types are compared case-insensitively.
+func (ls Links) ByType(mediaType string) Links {
+	return ls.Filter(func(l Link) bool {
+		return strings.EqualFold(l.Type, mediaType)
+	})
+}
+
+// ByHREFLang returns the links with the given hreflang, in order. Language tags are compared case-insensitively.
+func (ls Links) ByHREFLang(tag string) Links {
+	return ls.Filter(func(l Link) bool {
+		return strings.EqualFold(l.HREFLang, tag)
+	})
+}
+
+// ByMedia returns the links with the given media, in order
+func (ls Links) ByMedia(media string) Links {
+	return ls.Filter(func(l Link) bool {
+		return l.Media == media
+	})
+}
+
+// String returns the links as a Link header field value as defined by RFC8288
+func (ls Links) String() string {
+
+	var result []string
+
+	for _, l := range ls {
+		result = append(result, l.String())
+	}
+
+	return strings.Join(result, ", ")
+
+}
+
+// AddTo adds the links to h as a single Link header field line, leaving any existing lines in place. Nothing is added
+// when there are no links.
+func (ls Links) AddTo(h http.Header) {
+
+	if len(ls) > 0 {
+		h.Add("Link", ls.String())
+	}
+
+}
+
+// Resolve returns a copy of the links whose targets and contexts are absolute URIs. See Link.Resolve
+func (ls Links) Resolve(base *url.URL) Links {
+
+	var result Links
+
+	for _, l := range ls {
+		result = append(result, l.Resolve(base))
+	}
+
+	return result
+
+}
@@ -0,0 +1,219 @@
+package rfc8288
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"net/http"
+)
+
+var _ = Describe("Links", func() {
+
+	DescribeTable(
+		"ParseLinks(header)",
+		func(in string, out Links) {
+
+			// when
+			result, err := ParseLinks(in)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(out))
+
+		},
+		Entry(
+			"empty",
+			``,
+			Links(nil),
+		),
+		Entry(
+			"single link",
+			`<https://example.com/?page=2>; rel="next"`,
+			Links{
+				{HREF: URL("https://example.com/?page=2"), Rel: "next"},
+			},
+		),
+		Entry(
+			"several links",
+			`<https://example.com/?page=2>; rel="next", <https://example.com/?page=0>; rel=prev,<https://example.com/?page=9>`,
+			Links{
+				{HREF: URL("https://example.com/?page=2"), Rel: "next"},
+				{HREF: URL("https://example.com/?page=0"), Rel: "prev"},
+				{HREF: URL("https://example.com/?page=9")},
+			},
+		),
+		Entry(
+			"commas within quoted strings and URI references",
+			`<https://example.com/a,b;c>; rel="next"; title="one, two; three", </d>; title="\"quoted\""`,
+			Links{
+				{HREF: URL("https://example.com/a,b;c"), Rel: "next", Title: "one, two; three"},
+				{HREF: URL("/d"), Title: `"quoted"`},
+			},
+		),
+		Entry(
+			"empty list elements",
+			` , <https://example.com/>; rel="next",, `,
+			Links{
+				{HREF: URL("https://example.com/"), Rel: "next"},
+			},
+		),
+	)
+
+	DescribeTable(
+		"ParseLinks(header) error cases",
+		func(in string, out error) {
+
+			// when
+			_, err := ParseLinks(in)
+
+			// then
+			Expect(err).To(Equal(out))
+
+		},
+		Entry("missing URI reference", `rel="next"`, ErrInvalidLink),
+		Entry("missing semicolon", `<https://example.com/> rel="next"`, ErrMissingSemicolon),
+		Entry("missing closing quote", `<https://example.com/>; rel="next, <https://example.com/>`, ErrMissingClosingQuote),
+		Entry("invalid second link", `<https://example.com/>; rel="next", rel="prev"`, ErrInvalidLink),
+	)
+
+	It("ParseHeader(h) should parse every Link header field line in order", func() {
+
+		// given
+		h := http.Header{}
+		h.Add("Link", `<https://example.com/?page=2>; rel="next", <https://example.com/?page=0>; rel="prev"`)
+		h.Add("Link", `<https://example.com/?page=9>; rel="last"`)
+
+		// when
+		result, err := ParseHeader(h)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(Links{
+			{HREF: URL("https://example.com/?page=2"), Rel: "next"},
+			{HREF: URL("https://example.com/?page=0"), Rel: "prev"},
+			{HREF: URL("https://example.com/?page=9"), Rel: "last"},
+		}))
+
+	})
+
+	It("ParseHeader(h) should return the first error", func() {
+
+		// given
+		h := http.Header{"Link": {`<https://example.com/>`, `invalid`}}
+
+		// when
+		_, err := ParseHeader(h)
+
+		// then
+		Expect(err).To(Equal(ErrInvalidLink))
+
+	})
+
+	links := Links{
+		{HREF: URL("https://example.com/?page=2"), Rel: "next", Type: "application/json"},
+		{HREF: URL("https://example.com/?page=2&lang=de"), Rel: "Next", HREFLang: "de", Media: "print"},
+		{HREF: URL("https://example.com/?page=0"), Rel: "prev"},
+	}
+
+	It("ByRel(rel) should return the links with the relation type, case-insensitively", func() {
+
+		// when
+		result := links.ByRel("next")
+
+		// then
+		Expect(result).To(Equal(Links{links[0], links[1]}))
+
+	})
+
+	It("ByRel(rel) should match any of a link's relation types", func() {
+
+		// given
+		ls, _ := ParseLinks(`</a>; rel="next prefetch", </b>; rel="prefetch"`)
+
+		// when
+		result := ls.ByRel("Prefetch")
+
+		// then
+		Expect(result).To(HaveLen(2))
+		Expect(ls.ByRel("next")).To(Equal(Links{ls[0]}))
+
+	})
+
+	It("First(rel) should return the first link with the relation type", func() {
+
+		// when
+		result, ok := links.First("NEXT")
+		_, missing := links.First("last")
+
+		// then
+		Expect(ok).To(BeTrue())
+		Expect(result).To(Equal(links[0]))
+		Expect(missing).To(BeFalse())
+
+	})
+
+	It("should filter by type, hreflang and media", func() {
+
+		// expect
+		Expect(links.ByType("Application/JSON")).To(Equal(Links{links[0]}))
+		Expect(links.ByHREFLang("DE")).To(Equal(Links{links[1]}))
+		Expect(links.ByMedia("print")).To(Equal(Links{links[1]}))
+		Expect(links.ByRel("next").ByHREFLang("en")).To(BeEmpty())
+
+	})
+
+	It("String() should join the links with commas", func() {
+
+		// when
+		result := links.String()
+
+		// then
+		Expect(result).To(Equal(`<https://example.com/?page=2>; rel="next"; type="application/json", ` +
+			`<https://example.com/?page=2&lang=de>; rel="Next"; hreflang="de"; media="print", ` +
+			`<https://example.com/?page=0>; rel="prev"`))
+
+	})
+
+	It("String() should be parsed by ParseLinks(header)", func() {
+
+		// when
+		result, err := ParseLinks(links.String())
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(links))
+
+	})
+
+	It("AddTo(h) should add a Link header field line", func() {
+
+		// given
+		h := http.Header{"Link": {`</>; rel="self"`}}
+
+		// when
+		links.ByRel("prev").AddTo(h)
+		Links{}.AddTo(h)
+
+		// then
+		Expect(h.Values("Link")).To(Equal([]string{`</>; rel="self"`, `<https://example.com/?page=0>; rel="prev"`}))
+
+	})
+
+	It("Resolve(base) should resolve every link", func() {
+
+		// given
+		base := URL("https://example.com/articles/")
+		ls, _ := ParseLinks(`<1>; rel="first", <2>; rel="next"; anchor="/"`)
+
+		// when
+		result := ls.Resolve(&base)
+
+		// then
+		Expect(result[0].HREF).To(Equal(URL("https://example.com/articles/1")))
+		Expect(result[0].Anchor).To(Equal(base))
+		Expect(result[1].HREF).To(Equal(URL("https://example.com/articles/2")))
+		Expect(result[1].Anchor).To(Equal(URL("https://example.com/")))
+
+	})
+
+})
@@ -0,0 +1,473 @@
+package rfc8288
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/tniswong/go.rfcx/internal/jsonobject"
+	"github.com/tniswong/go.rfcx/rfc7231"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// LinksetMediaType is the media type of a linkset in the format of a Link header field value, as defined by
+	// RFC9264 Sec. 4.1
+	LinksetMediaType = "application/linkset"
+
+	// LinksetJSONMediaType is the media type of a linkset in JSON, as defined by RFC9264 Sec. 4.2
+	LinksetJSONMediaType = "application/linkset+json"
+
+	// APICatalogPath is the well-known location of an API catalog, a linkset of the APIs published by a host, as
+	// defined by RFC9727
+	APICatalogPath = "/.well-known/api-catalog"
+)
+
+var (
+	// ErrInvalidLinkset describes a JSON document that is not a linkset as defined by RFC9264 Sec. 4.2
+	ErrInvalidLinkset = errors.New("rfc8288: invalid linkset")
+
+	// ErrMissingRelationType describes an attempt to marshal a link without a relation type as a JSON linkset
+	ErrMissingRelationType = errors.New("rfc8288: a link in a JSON linkset must have a relation type")
+)
+
+// Linkset is a set of links, as defined by RFC9264, whose contexts are given by their anchors. A link without an
+// anchor has the linkset resource as its context.
+type Linkset Links
+
+// ParseLinkset attempts to parse an application/linkset document, a Link header field value in which links may also
+// be separated by newlines
+func ParseLinkset(document string) (Linkset, error) {
+
+	links, err := ParseLinks(document)
+	return Linkset(links), err
+
+}
+
+// String returns the Linkset as an application/linkset document, with each link on its own line
+func (ls Linkset) String() string {
+
+	var result []string
+
+	for _, l := range ls {
+		result = append(result, l.String())
+	}
+
+	return strings.Join(result, ",\n")
+
+}
+
+// linksetContext is a link context object of a JSON linkset, holding the target objects of its links by relation
+// type
+type linksetContext struct {
+	anchor  string
+	rels    []string
+	targets map[string][]map[string]interface{}
+}
+
+// MarshalJSON marshals the Linkset as an application/linkset+json document. Links are grouped into link context
+// objects by anchor, then by relation type, in the order they appear. Target attributes are represented as described
+// by RFC9264 Sec. 4.2.4, e.g. hreflang as an array and title* as an array of language-tagged objects.
+func (ls Linkset) MarshalJSON() ([]byte, error) {
+
+	var (
+		contexts []*linksetContext
+		byAnchor = map[string]*linksetContext{}
+	)
+
+	for _, l := range ls {
+
+		rels := strings.Fields(l.Rel)
+
+		if len(rels) == 0 {
+			return nil, ErrMissingRelationType
+		}
+
+		anchor := l.Anchor.String()
+		ctx, ok := byAnchor[anchor]
+
+		if !ok {
+			ctx = &linksetContext{anchor: anchor, targets: map[string][]map[string]interface{}{}}
+			byAnchor[anchor] = ctx
+			contexts = append(contexts, ctx)
+		}
+
+		for _, rel := range rels {
+
+			// registered relation types are compared case-insensitively, extension relation types are URIs
+			if !IsExtensionRelation(rel) {
+				rel = strings.ToLower(rel)
+			}
+
+			if _, ok := ctx.targets[rel]; !ok {
+				ctx.rels = append(ctx.rels, rel)
+			}
+
+			ctx.targets[rel] = append(ctx.targets[rel], linksetTarget(l))
+
+		}
+
+	}
+
+	var out []map[string]interface{}
+
+	for _, ctx := range contexts {
+
+		object := map[string]interface{}{}
+
+		if ctx.anchor != "" {
+			object["anchor"] = ctx.anchor
+		}
+
+		for _, rel := range ctx.rels {
+			object[rel] = ctx.targets[rel]
+		}
+
+		out = append(out, object)
+
+	}
+
+	if out == nil {
+		out = []map[string]interface{}{}
+	}
+
+	return json.Marshal(map[string]interface{}{"linkset": out})
+
+}
+
+// linksetTarget returns the target object of a link in a JSON linkset
+func linksetTarget(l Link) map[string]interface{} {
+
+	target := map[string]interface{}{"href": l.HREF.String()}
+
+	if l.HREFLang != "" {
+		target["hreflang"] = []string{l.HREFLang}
+	}
+
+	if l.Media != "" {
+		target["media"] = l.Media
+	}
+
+	if l.Title != "" {
+		target["title"] = l.Title
+	}
+
+	if l.TitleStar != (ExtValue{}) {
+		target["title*"] = []linksetExtValue{{Value: l.TitleStar.Value, Language: l.TitleStar.Language}}
+	}
+
+	if l.Type != "" {
+		target["type"] = l.Type
+	}
+
+	for _, key := range l.extensionKeys {
+
+		switch v := l.extensions[key].(type) {
+		case ExtValue:
+			target[key] = []linksetExtValue{{Value: v.Value, Language: v.Language}}
+		case []string:
+			target[key] = v
+		case string:
+			target[key] = []string{v}
+		case bool:
+
+			// a parameter without a value is represented by an empty string
+			if v {
+				target[key] = []string{""}
+			} else {
+				target[key] = []string{"false"}
+			}
+
+		default:
+			target[key] = []string{fmt.Sprint(v)}
+		}
+
+	}
+
+	return target
+
+}
+
+// linksetExtValue is the JSON representation of an ExtValue in a linkset, which is always UTF-8
+type linksetExtValue struct {
+	Value    string `json:"value"`
+	Language string `json:"language,omitempty"`
+}
+
+// UnmarshalJSON unmarshals an application/linkset+json document. Each target object becomes a Link whose Rel is the
+// relation type it is listed under, in document order. As a Link holds a single hreflang and title*, only the first
+// of each is kept. Extension target attributes with a single value are strings, and otherwise []string.
+func (ls *Linkset) UnmarshalJSON(data []byte) error {
+
+	var document struct {
+		Linkset []json.RawMessage `json:"linkset"`
+	}
+
+	if err := json.Unmarshal(data, &document); err != nil || document.Linkset == nil {
+		return ErrInvalidLinkset
+	}
+
+	result := Linkset{}
+
+	for _, raw := range document.Linkset {
+
+		members, err := linksetObject(raw)
+
+		if err != nil {
+			return err
+		}
+
+		var anchor url.URL
+
+		for _, m := range members {
+
+			if m.Name != "anchor" {
+				continue
+			}
+
+			var str string
+
+			if err := json.Unmarshal(m.Value, &str); err != nil {
+				return ErrInvalidLinkset
+			}
+
+			uri, err := url.Parse(str)
+
+			if err != nil {
+				return ErrInvalidLinkset
+			}
+
+			anchor = *uri
+
+		}
+
+		for _, m := range members {
+
+			if m.Name == "anchor" {
+				continue
+			}
+
+			var targets []json.RawMessage
+
+			if err := json.Unmarshal(m.Value, &targets); err != nil {
+				return ErrInvalidLinkset
+			}
+
+			for _, target := range targets {
+
+				l, err := linksetLink(target)
+
+				if err != nil {
+					return err
+				}
+
+				l.Anchor = anchor
+				l.Rel = m.Name
+
+				result = append(result, l)
+
+			}
+
+		}
+
+	}
+
+	*ls = result
+	return nil
+
+}
+
+// linksetLink returns the Link described by a target object of a JSON linkset, without its context or relation type
+func linksetLink(data []byte) (Link, error) {
+
+	members, err := linksetObject(data)
+
+	if err != nil {
+		return Link{}, err
+	}
+
+	var (
+		l       Link
+		hasHREF bool
+	)
+
+	for _, m := range members {
+
+		key, member := m.Name, m.Value
+
+		switch key {
+		case "href":
+
+			var str string
+
+			if err := json.Unmarshal(member, &str); err != nil {
+				return Link{}, ErrInvalidLinkset
+			}
+
+			uri, err := url.Parse(str)
+
+			if err != nil {
+				return Link{}, ErrInvalidLinkset
+			}
+
+			l.HREF = *uri
+			hasHREF = true
+
+		case "media", "title", "type":
+
+			var str string
+
+			if err := json.Unmarshal(member, &str); err != nil {
+				return Link{}, ErrInvalidLinkset
+			}
+
+			switch key {
+			case "media":
+				l.Media = str
+			case "title":
+				l.Title = str
+			default:
+				l.Type = str
+			}
+
+		case "hreflang":
+
+			var tags []string
+
+			if err := json.Unmarshal(member, &tags); err != nil {
+				return Link{}, ErrInvalidLinkset
+			}
+
+			if len(tags) > 0 {
+				l.HREFLang = tags[0]
+			}
+
+		default:
+
+			value, err := linksetAttribute(key, member)
+
+			if err != nil {
+				return Link{}, err
+			}
+
+			if key == "title*" {
+
+				if ext, ok := value.(ExtValue); ok {
+					l.TitleStar = ext
+				}
+
+				continue
+
+			}
+
+			if value == nil {
+				continue
+			}
+
+			if err := l.Extend(key, value); err != nil {
+				return Link{}, ErrInvalidLinkset
+			}
+
+		}
+
+	}
+
+	if !hasHREF {
+		return Link{}, ErrInvalidLinkset
+	}
+
+	return l, nil
+
+}
+
+// linksetAttribute returns the value of a target attribute, an array of strings or, for extended parameters, of
+// language-tagged objects. Only the first language-tagged object is kept, and nil is returned for an empty array.
+func linksetAttribute(key string, data []byte) (interface{}, error) {
+
+	if strings.HasSuffix(key, "*") {
+
+		var values []linksetExtValue
+
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, ErrInvalidLinkset
+		}
+
+		if len(values) == 0 {
+			return nil, nil
+		}
+
+		return ExtValue{Charset: "UTF-8", Language: values[0].Language, Value: values[0].Value}, nil
+
+	}
+
+	var values []string
+
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, ErrInvalidLinkset
+	}
+
+	switch len(values) {
+	case 0:
+		return nil, nil
+	case 1:
+		return values[0], nil
+	default:
+		return values, nil
+	}
+
+}
+
+// linksetObject returns the members of a link context or target object of a JSON linkset, in document order. As
+// with encoding/json, the last of repeated names is kept.
+func linksetObject(data []byte) ([]jsonobject.Member, error) {
+
+	if !jsonobject.IsObject(data) {
+		return nil, ErrInvalidLinkset
+	}
+
+	members, err := jsonobject.Members(data)
+
+	if err != nil {
+		return nil, ErrInvalidLinkset
+	}
+
+	return jsonobject.Unique(members), nil
+
+}
+
+// ServeHTTP implements http.Handler by writing the Linkset as the response, e.g. at APICatalogPath. The representation
+// is negotiated from the request's Accept header between LinksetJSONMediaType and LinksetMediaType, preferring
+// LinksetJSONMediaType when neither is acceptable.
+func (ls Linkset) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	var (
+		mediaType = LinksetJSONMediaType
+		body      []byte
+		err       error
+	)
+
+	if accept, parseErr := rfc7231.ParseAccept(r.Header.Get("Accept")); parseErr == nil {
+
+		if acceptable, ok := accept.MostAcceptable([]string{LinksetJSONMediaType, LinksetMediaType}); ok {
+			mediaType = acceptable
+		}
+
+	}
+
+	if mediaType == LinksetMediaType {
+		body = []byte(ls.String())
+	} else {
+		body, err = json.Marshal(ls)
+	}
+
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+
+}
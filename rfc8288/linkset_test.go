@@ -0,0 +1,268 @@
+package rfc8288
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Linkset", func() {
+
+	linkset := func() Linkset {
+
+		author := Link{
+			HREF:      URL("https://example.com/people/alice"),
+			Anchor:    URL("https://example.com/articles/1"),
+			Rel:       "author",
+			HREFLang:  "en",
+			TitleStar: ExtValue{Charset: "UTF-8", Language: "de", Value: "Über Alice"},
+		}
+		author.Extend("role", "editor")
+
+		return Linkset{
+			author,
+			{HREF: URL("https://example.com/articles/2"), Anchor: URL("https://example.com/articles/1"), Rel: "next", Type: "text/html"},
+			{HREF: URL("https://example.com/articles/1"), Anchor: URL("https://example.com/articles/2"), Rel: "prev"},
+			{HREF: URL("https://example.com/describedby"), Rel: "describedby https://example.com/rels/Meta", Title: "About"},
+		}
+
+	}
+
+	document := `{
+		"linkset": [
+			{
+				"anchor": "https://example.com/articles/1",
+				"author": [
+					{
+						"href": "https://example.com/people/alice",
+						"hreflang": ["en"],
+						"title*": [{"value": "Über Alice", "language": "de"}],
+						"role": ["editor"]
+					}
+				],
+				"next": [{"href": "https://example.com/articles/2", "type": "text/html"}]
+			},
+			{
+				"anchor": "https://example.com/articles/2",
+				"prev": [{"href": "https://example.com/articles/1"}]
+			},
+			{
+				"describedby": [{"href": "https://example.com/describedby", "title": "About"}],
+				"https://example.com/rels/Meta": [{"href": "https://example.com/describedby", "title": "About"}]
+			}
+		]
+	}`
+
+	It("MarshalJSON() should group links by anchor and relation type", func() {
+
+		// when
+		data, err := json.Marshal(linkset())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(MatchJSON(document))
+
+	})
+
+	It("MarshalJSON() should marshal an empty linkset", func() {
+
+		// when
+		data, err := json.Marshal(Linkset{})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"linkset": []}`))
+
+	})
+
+	It("MarshalJSON() should represent extensions as arrays", func() {
+
+		// given
+		l := Link{HREF: URL("https://example.com"), Rel: "item"}
+		l.Extend("crossorigin", true)
+		l.Extend("count", 3)
+		l.Extend("tags", []string{"a", "b"})
+		l.Extend("note*", ExtValue{Value: "café"})
+
+		// when
+		data, err := json.Marshal(Linkset{l})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"linkset": [
+				{
+					"item": [
+						{
+							"href": "https://example.com",
+							"crossorigin": [""],
+							"count": ["3"],
+							"tags": ["a", "b"],
+							"note*": [{"value": "café"}]
+						}
+					]
+				}
+			]
+		}`))
+
+	})
+
+	It("MarshalJSON() should return ErrMissingRelationType for a link without a relation type", func() {
+
+		// when
+		_, err := Linkset{{HREF: URL("https://example.com")}}.MarshalJSON()
+
+		// then
+		Expect(err).To(Equal(ErrMissingRelationType))
+
+	})
+
+	It("UnmarshalJSON(data) should return a link per relation type and target, in document order", func() {
+
+		// given
+		result := Linkset{}
+
+		// when
+		err := json.Unmarshal([]byte(document), &result)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		expected := linkset()
+		expected[3].Rel = "describedby"
+
+		meta := expected[3]
+		meta.Rel = "https://example.com/rels/Meta"
+
+		Expect(result).To(Equal(append(expected, meta)))
+
+	})
+
+	It("UnmarshalJSON(data) should keep several extension values and the first hreflang", func() {
+
+		// given
+		result := Linkset{}
+
+		// when
+		err := json.Unmarshal([]byte(`{
+			"linkset": [
+				{"alternate": [{"href": "/de", "hreflang": ["de", "de-AT"], "tags": ["a", "b"], "empty": []}]}
+			]
+		}`), &result)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].HREFLang).To(Equal("de"))
+		Expect(result[0].ExtensionKeys()).To(Equal([]string{"tags"}))
+
+		tags, _ := result[0].Extension("tags")
+		Expect(tags).To(Equal([]string{"a", "b"}))
+
+	})
+
+	It("UnmarshalJSON(data) should keep the last of repeated members, as encoding/json does", func() {
+
+		// given
+		result := Linkset{}
+
+		// when
+		err := json.Unmarshal([]byte(`{
+			"linkset": [
+				{"next": [{"href": "/2"}], "prev": [{"href": "/0"}], "next": [{"href": "/3", "href": "/4"}]}
+			]
+		}`), &result)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(Linkset{{HREF: URL("/4"), Rel: "next"}, {HREF: URL("/0"), Rel: "prev"}}))
+
+	})
+
+	DescribeTable(
+		"UnmarshalJSON(data) should return ErrInvalidLinkset",
+		func(in string) {
+
+			// given
+			result := Linkset{}
+
+			// when
+			err := json.Unmarshal([]byte(in), &result)
+
+			// then
+			Expect(err).To(Equal(ErrInvalidLinkset))
+
+		},
+		Entry("missing linkset", `{}`),
+		Entry("linkset not an array", `{"linkset": {}}`),
+		Entry("context not an object", `{"linkset": [[]]}`),
+		Entry("context null", `{"linkset": [null]}`),
+		Entry("target null", `{"linkset": [{"next": [null]}]}`),
+		Entry("anchor not a string", `{"linkset": [{"anchor": 1}]}`),
+		Entry("targets not an array", `{"linkset": [{"next": {"href": "/"}}]}`),
+		Entry("missing href", `{"linkset": [{"next": [{"type": "text/html"}]}]}`),
+		Entry("hreflang not an array", `{"linkset": [{"next": [{"href": "/", "hreflang": "en"}]}]}`),
+		Entry("title* not language-tagged", `{"linkset": [{"next": [{"href": "/", "title*": ["title"]}]}]}`),
+		Entry("reserved target attribute", `{"linkset": [{"next": [{"href": "/", "rel": ["prev"]}]}]}`),
+	)
+
+	It("String() should format one link per line", func() {
+
+		// when
+		result := linkset()[1:3].String()
+
+		// then
+		Expect(result).To(Equal(`<https://example.com/articles/2>; rel="next"; anchor="https://example.com/articles/1"; type="text/html",` +
+			"\n" + `<https://example.com/articles/1>; rel="prev"; anchor="https://example.com/articles/2"`))
+
+	})
+
+	It("ParseLinkset(String()) should round trip", func() {
+
+		// given
+		in := linkset()
+
+		// when
+		result, err := ParseLinkset(in.String())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(in))
+
+	})
+
+	DescribeTable(
+		"ServeHTTP should negotiate the linkset format",
+		func(accept string, mediaType string) {
+
+			// given
+			ls := linkset()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, APICatalogPath, nil)
+			r.Header.Set("Accept", accept)
+
+			// when
+			ls.ServeHTTP(w, r)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("Content-Type")).To(Equal(mediaType))
+
+			if mediaType == LinksetMediaType {
+				Expect(w.Body.String()).To(Equal(ls.String()))
+			} else {
+				Expect(w.Body.Bytes()).To(MatchJSON(document))
+			}
+
+		},
+		Entry("no Accept", "", LinksetJSONMediaType),
+		Entry("linkset+json", LinksetJSONMediaType, LinksetJSONMediaType),
+		Entry("linkset", LinksetMediaType, LinksetMediaType),
+		Entry("preferring linkset", "application/linkset+json;q=0.5, application/linkset", LinksetMediaType),
+		Entry("neither", "text/html", LinksetJSONMediaType),
+	)
+
+})
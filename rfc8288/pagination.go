@@ -0,0 +1,291 @@
+package rfc8288
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultPageParam is the query parameter holding the page number when Page.Param is unset
+	DefaultPageParam = "page"
+
+	// DefaultCursorParam is the query parameter holding the cursor when Cursor.Param is unset
+	DefaultCursorParam = "cursor"
+
+	// DefaultMaxPages is the number of pages a Pager fetches when MaxPages is unset
+	DefaultMaxPages = 100
+)
+
+var (
+	// ErrPaginationLoop describes a next link referring to a page that has already been fetched
+	ErrPaginationLoop = errors.New("rfc8288: pagination loop, next link refers to a page already fetched")
+
+	// ErrMaxPages describes a Pager stopping at its MaxPages although there is a next link
+	ErrMaxPages = errors.New("rfc8288: pagination exceeded the maximum number of pages")
+)
+
+// Page describes the current page of a collection paginated by page number
+type Page struct {
+	// Param is the query parameter holding the page number, DefaultPageParam if unset
+	Param string
+
+	// Number is the current page number, starting at 1
+	Number int
+
+	// Last is the number of the last page, or 0 if unknown
+	Last int
+
+	// More is whether there is a next page when Last is unknown
+	More bool
+}
+
+// Links returns the first, prev, next and last links of the page, as applicable, to u with the page number set in its
+// query. Other query parameters of u are preserved.
+func (p Page) Links(u url.URL) Links {
+
+	param := p.Param
+
+	if param == "" {
+		param = DefaultPageParam
+	}
+
+	link := func(rel string, number int) Link {
+		return Link{HREF: withQuery(u, param, strconv.Itoa(number)), Rel: rel}
+	}
+
+	result := Links{link(RelFirst, 1)}
+
+	if p.Number > 1 {
+		result = append(result, link(RelPrev, p.Number-1))
+	}
+
+	if p.Number < p.Last || (p.Last == 0 && p.More) {
+		result = append(result, link(RelNext, p.Number+1))
+	}
+
+	if p.Last > 0 {
+		result = append(result, link(RelLast, p.Last))
+	}
+
+	return result
+
+}
+
+// Cursor describes the current page of a collection paginated by opaque cursors
+type Cursor struct {
+	// Param is the query parameter holding the cursor, DefaultCursorParam if unset
+	Param string
+
+	// Prev, Next and Last are the cursors of the respective pages, empty if there is no such page
+	Prev string
+	Next string
+	Last string
+}
+
+// Links returns the first, prev, next and last links of the page, as applicable, to u with the cursor set in its query.
+// The first link has no cursor. Other query parameters of u are preserved.
+func (c Cursor) Links(u url.URL) Links {
+
+	param := c.Param
+
+	if param == "" {
+		param = DefaultCursorParam
+	}
+
+	result := Links{{HREF: withQuery(u, param, ""), Rel: RelFirst}}
+
+	for _, page := range []struct{ rel, cursor string }{
+		{RelPrev, c.Prev},
+		{RelNext, c.Next},
+		{RelLast, c.Last},
+	} {
+
+		if page.cursor != "" {
+			result = append(result, Link{HREF: withQuery(u, param, page.cursor), Rel: page.rel})
+		}
+
+	}
+
+	return result
+
+}
+
+// withQuery returns a copy of u with the query parameter set to value, or removed when value is empty
+func withQuery(u url.URL, param string, value string) url.URL {
+
+	query := u.Query()
+
+	if value == "" {
+		query.Del(param)
+	} else {
+		query.Set(param, value)
+	}
+
+	u.RawQuery = query.Encode()
+
+	return u
+
+}
+
+// Pager follows the next links of paginated responses, fetching one page per call to Next:
+//
+//	pager := NewPager(http.DefaultClient, req)
+//
+//	for pager.Next() {
+//		res := pager.Response()
+//		...
+//	}
+//
+//	if err := pager.Err(); err != nil {
+//		...
+//	}
+//
+// Every page is requested with the method and header fields of the first request, which must not have a body, except
+// that the Authorization, Cookie and Proxy-Authorization header fields are not sent to a next link of another origin.
+// A Pager is not safe for concurrent use.
+type Pager struct {
+	// MaxPages is the maximum number of pages fetched, DefaultMaxPages if unset
+	MaxPages int
+
+	client   *http.Client
+	request  *http.Request
+	response *http.Response
+	fetched  map[string]struct{}
+	err      error
+}
+
+// NewPager returns a Pager whose first page is the response to req, sent using client. A nil client is
+// http.DefaultClient.
+func NewPager(client *http.Client, req *http.Request) *Pager {
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Pager{client: client, request: req, fetched: map[string]struct{}{}}
+
+}
+
+// Next fetches the next page, closing the body of the previous response. It returns false once there is no next
+// link, or on error, which is then reported by Err.
+func (p *Pager) Next() bool {
+
+	if p.err != nil {
+		return false
+	}
+
+	var request *http.Request
+
+	if p.response == nil {
+
+		if len(p.fetched) > 0 {
+			return false
+		}
+
+		request = p.request
+
+	} else {
+
+		p.response.Body.Close()
+
+		next, ok := p.next()
+		p.response = nil
+
+		if !ok {
+			return false
+		}
+
+		request = next
+
+	}
+
+	max := p.MaxPages
+
+	if max <= 0 {
+		max = DefaultMaxPages
+	}
+
+	if _, ok := p.fetched[request.URL.String()]; ok {
+		p.err = ErrPaginationLoop
+		return false
+	}
+
+	if len(p.fetched) >= max {
+		p.err = ErrMaxPages
+		return false
+	}
+
+	p.fetched[request.URL.String()] = struct{}{}
+
+	response, err := p.client.Do(request)
+
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	p.response = response
+
+	return true
+
+}
+
+// next returns the request for the target of the current response's next link, if any
+func (p *Pager) next() (*http.Request, bool) {
+
+	links, err := ParseHeader(p.response.Header)
+
+	if err != nil {
+		p.err = err
+		return nil, false
+	}
+
+	base := p.request.URL
+
+	if p.response.Request != nil {
+		base = p.response.Request.URL
+	}
+
+	link, ok := links.Resolve(base).First(RelNext)
+
+	if !ok {
+		return nil, false
+	}
+
+	request := p.request.Clone(p.request.Context())
+	request.URL = &link.HREF
+	request.Host = ""
+
+	// as with redirects, credentials are not sent to another origin
+	if !sameOrigin(p.request.URL, request.URL) {
+
+		for _, name := range credentialHeaders {
+			request.Header.Del(name)
+		}
+
+	}
+
+	return request, true
+
+}
+
+// credentialHeaders holds the header fields a Pager does not send when following a next link to another origin
+var credentialHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
+// sameOrigin returns whether u and v have the same scheme and host, compared case-insensitively
+func sameOrigin(u *url.URL, v *url.URL) bool {
+	return strings.EqualFold(u.Scheme, v.Scheme) && strings.EqualFold(u.Host, v.Host)
+}
+
+// Response returns the response of the current page
+func (p *Pager) Response() *http.Response {
+	return p.response
+}
+
+// Err returns the first error encountered while paginating, or nil if every page was fetched
+func (p *Pager) Err() error {
+	return p.err
+}
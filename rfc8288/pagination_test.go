@@ -0,0 +1,259 @@
+package rfc8288
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+)
+
+var _ = Describe("Pagination", func() {
+
+	base := URL("https://example.com/items?sort=name&page=3")
+
+	DescribeTable(
+		"Page.Links(u)",
+		func(in Page, out string) {
+
+			// when
+			result := in.Links(base)
+
+			// then
+			Expect(result.String()).To(Equal(out))
+
+		},
+		Entry(
+			"first page",
+			Page{Number: 1, Last: 3},
+			`<https://example.com/items?page=1&sort=name>; rel="first", `+
+				`<https://example.com/items?page=2&sort=name>; rel="next", `+
+				`<https://example.com/items?page=3&sort=name>; rel="last"`,
+		),
+		Entry(
+			"middle page",
+			Page{Number: 2, Last: 3},
+			`<https://example.com/items?page=1&sort=name>; rel="first", `+
+				`<https://example.com/items?page=1&sort=name>; rel="prev", `+
+				`<https://example.com/items?page=3&sort=name>; rel="next", `+
+				`<https://example.com/items?page=3&sort=name>; rel="last"`,
+		),
+		Entry(
+			"last page",
+			Page{Number: 3, Last: 3},
+			`<https://example.com/items?page=1&sort=name>; rel="first", `+
+				`<https://example.com/items?page=2&sort=name>; rel="prev", `+
+				`<https://example.com/items?page=3&sort=name>; rel="last"`,
+		),
+		Entry(
+			"unknown last page, with more",
+			Page{Param: "p", Number: 2, More: true},
+			`<https://example.com/items?p=1&page=3&sort=name>; rel="first", `+
+				`<https://example.com/items?p=1&page=3&sort=name>; rel="prev", `+
+				`<https://example.com/items?p=3&page=3&sort=name>; rel="next"`,
+		),
+		Entry(
+			"unknown last page, without more",
+			Page{Number: 1},
+			`<https://example.com/items?page=1&sort=name>; rel="first"`,
+		),
+	)
+
+	DescribeTable(
+		"Cursor.Links(u)",
+		func(in Cursor, out string) {
+
+			// given
+			u := URL("/items?sort=name&cursor=abc")
+
+			// when
+			result := in.Links(u)
+
+			// then
+			Expect(result.String()).To(Equal(out))
+
+		},
+		Entry(
+			"every cursor",
+			Cursor{Prev: "aaa", Next: "ccc", Last: "zzz"},
+			`</items?sort=name>; rel="first", `+
+				`</items?cursor=aaa&sort=name>; rel="prev", `+
+				`</items?cursor=ccc&sort=name>; rel="next", `+
+				`</items?cursor=zzz&sort=name>; rel="last"`,
+		),
+		Entry(
+			"next cursor only",
+			Cursor{Param: "after", Next: "a b"},
+			`</items?cursor=abc&sort=name>; rel="first", `+
+				`</items?after=a+b&cursor=abc&sort=name>; rel="next"`,
+		),
+	)
+
+	Describe("Pager", func() {
+
+		// server serves pages 1 to pages of a collection, linking each to the next with the given target
+		server := func(pages int, next func(page int) string) *httptest.Server {
+			return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+				if page == 0 {
+					page = 1
+				}
+
+				if page < pages {
+					Links{{HREF: URL(next(page)), Rel: RelNext}}.AddTo(w.Header())
+				}
+
+				fmt.Fprintf(w, "%d %s", page, r.Header.Get("Accept"))
+
+			}))
+		}
+
+		bodies := func(pager *Pager) []string {
+
+			var result []string
+
+			for pager.Next() {
+				body, _ := io.ReadAll(pager.Response().Body)
+				result = append(result, string(body))
+			}
+
+			return result
+
+		}
+
+		It("should follow next links until exhausted, resolving them and repeating header fields", func() {
+
+			// given
+			s := server(3, func(page int) string { return fmt.Sprintf("?page=%d", page+1) })
+			defer s.Close()
+
+			req, _ := http.NewRequest(http.MethodGet, s.URL+"/items", nil)
+			req.Header.Set("Accept", "application/json")
+
+			pager := NewPager(s.Client(), req)
+
+			// when
+			result := bodies(pager)
+
+			// then
+			Expect(pager.Err()).ToNot(HaveOccurred())
+			Expect(result).To(Equal([]string{"1 application/json", "2 application/json", "3 application/json"}))
+			Expect(pager.Next()).To(BeFalse())
+
+		})
+
+		It("should stop with ErrPaginationLoop when a next link refers to a page already fetched", func() {
+
+			// given
+			s := server(5, func(page int) string { return "?page=1" })
+			defer s.Close()
+
+			req, _ := http.NewRequest(http.MethodGet, s.URL+"/items?page=1", nil)
+			pager := NewPager(s.Client(), req)
+
+			// when
+			result := bodies(pager)
+
+			// then
+			Expect(pager.Err()).To(Equal(ErrPaginationLoop))
+			Expect(result).To(HaveLen(1))
+
+		})
+
+		It("should stop with ErrMaxPages after MaxPages pages", func() {
+
+			// given
+			s := server(5, func(page int) string { return fmt.Sprintf("?page=%d", page+1) })
+			defer s.Close()
+
+			req, _ := http.NewRequest(http.MethodGet, s.URL, nil)
+			pager := NewPager(s.Client(), req)
+			pager.MaxPages = 2
+
+			// when
+			result := bodies(pager)
+
+			// then
+			Expect(pager.Err()).To(Equal(ErrMaxPages))
+			Expect(result).To(HaveLen(2))
+
+		})
+
+		It("should not send credentials to a next link of another origin", func() {
+
+			// given
+			var received []http.Header
+
+			record := func(w http.ResponseWriter, r *http.Request) {
+				received = append(received, r.Header.Clone())
+			}
+
+			other := httptest.NewServer(http.HandlerFunc(record))
+			defer other.Close()
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+				record(w, r)
+
+				if r.URL.Query().Get("page") == "" {
+					Links{{HREF: URL("?page=2"), Rel: RelNext}}.AddTo(w.Header())
+				} else {
+					Links{{HREF: URL(other.URL + "/steal"), Rel: RelNext}}.AddTo(w.Header())
+				}
+
+			}))
+			defer s.Close()
+
+			req, _ := http.NewRequest(http.MethodGet, s.URL, nil)
+			req.Header.Set("Authorization", "Bearer secret")
+			req.Header.Set("Cookie", "session=secret")
+			req.Header.Set("Proxy-Authorization", "Basic secret")
+			req.Header.Set("Accept", "application/json")
+
+			pager := NewPager(s.Client(), req)
+
+			// when
+			result := bodies(pager)
+
+			// then
+			Expect(pager.Err()).ToNot(HaveOccurred())
+			Expect(result).To(HaveLen(3))
+			Expect(received).To(HaveLen(3))
+
+			Expect(received[1].Get("Authorization")).To(Equal("Bearer secret"))
+			Expect(received[1].Get("Cookie")).To(Equal("session=secret"))
+
+			Expect(received[2]).ToNot(HaveKey("Authorization"))
+			Expect(received[2]).ToNot(HaveKey("Cookie"))
+			Expect(received[2]).ToNot(HaveKey("Proxy-Authorization"))
+			Expect(received[2].Get("Accept")).To(Equal("application/json"))
+			Expect(req.Header.Get("Authorization")).To(Equal("Bearer secret"))
+
+		})
+
+		It("should report errors sending requests", func() {
+
+			// given
+			s := server(1, nil)
+			s.Close()
+
+			req, _ := http.NewRequest(http.MethodGet, s.URL, nil)
+			pager := NewPager(nil, req)
+
+			// when
+			result := bodies(pager)
+
+			// then
+			Expect(pager.Err()).To(HaveOccurred())
+			Expect(result).To(BeEmpty())
+
+		})
+
+	})
+
+})
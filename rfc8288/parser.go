@@ -3,6 +3,7 @@ package rfc8288
 import (
 	"errors"
 	"net/url"
+	"strings"
 )
 
 // parse Error Types
@@ -15,10 +16,31 @@ var (
 
 type parser struct {
 	scanner scanner
+	buffer  struct {
+		token     token
+		literal   string
+		unscanned bool
+	}
+
+	// list is true when parsing a comma-separated list of links, and ended is set once the current link is terminated
+	list  bool
+	ended bool
 }
 
 func (p *parser) scan() (token, string, error) {
-	return p.scanner.Scan()
+
+	if p.buffer.unscanned {
+		p.buffer.unscanned = false
+		return p.buffer.token, p.buffer.literal, nil
+	}
+
+	token, literal, err := p.scanner.Scan()
+
+	p.buffer.token = token
+	p.buffer.literal = literal
+
+	return token, literal, err
+
 }
 
 func (p *parser) scanIgnoreWhitespace() (token, string, error) {
@@ -33,9 +55,72 @@ func (p *parser) scanIgnoreWhitespace() (token, string, error) {
 
 }
 
+func (p *parser) unscan() {
+	p.buffer.unscanned = true
+}
+
+// terminates returns whether the token terminates the current link, recording that it has ended
+func (p *parser) terminates(t token) bool {
+
+	if t == EOF || (t == COMMA && p.list) {
+		p.ended = true
+		return true
+	}
+
+	return false
+
+}
+
 func (p parser) parse() (Link, error) {
+	return p.link()
+}
+
+// links parses a comma-separated list of links. Empty list elements are ignored.
+func (p *parser) links() (Links, error) {
+
+	p.list = true
+
+	var result Links
+
+	for {
+
+		token, _, err := p.scanIgnoreWhitespace()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if token == EOF {
+			return result, nil
+		}
+
+		if token == COMMA {
+			continue
+		}
+
+		p.unscan()
+
+		link, err := p.link()
 
-	var result = Link{}
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, link)
+
+	}
+
+}
+
+// link parses a single link, up to and including its terminator
+func (p *parser) link() (Link, error) {
+
+	var (
+		result = Link{}
+		relSet bool
+	)
+
+	p.ended = false
 	href, err := p.href()
 
 	if err != nil {
@@ -44,36 +129,78 @@ func (p parser) parse() (Link, error) {
 
 	result.HREF = href
 
-loop:
-	for {
+	for !p.ended {
 
-		token, key, value, hasStar, err := p.attribute()
+		attr, err := p.attribute()
 
 		if err != nil {
 			return Link{}, err
 		}
 
-		switch token {
+		switch attr.token {
 		case REL:
-			result.Rel = value
+
+			// occurrences after the first are ignored, as required by RFC 8288 Sec. 3.3
+			if !relSet {
+				result.Rel = attr.value
+				relSet = true
+			}
+
 		case HREFLANG:
-			result.HREFLang = value
+			result.HREFLang = attr.value
 		case MEDIA:
-			result.Media = value
+			result.Media = attr.value
 		case TITLE:
 
-			if hasStar {
-				result.TitleStar = value
-			} else {
-				result.Title = value
+			if !attr.star {
+				result.Title = attr.value
+				break
+			}
+
+			ext, err := ParseExtValue(attr.value)
+
+			if err != nil {
+				return Link{}, err
 			}
 
+			result.TitleStar = ext
+
 		case TYPE:
-			result.Type = value
+			result.Type = attr.value
 		case WORD:
-			result.Extend(key, value)
+
+			if attr.valueless {
+				result.Extend(attr.key, true)
+				break
+			}
+
+			if attr.star {
+
+				ext, err := ParseExtValue(attr.value)
+
+				if err != nil {
+					return Link{}, err
+				}
+
+				result.Extend(attr.key+"*", ext)
+				break
+
+			}
+
+			if !strings.EqualFold(attr.key, "anchor") {
+				result.Extend(attr.key, attr.value)
+				break
+			}
+
+			anchor, err := url.Parse(attr.value)
+
+			if err != nil {
+				return Link{}, err
+			}
+
+			result.Anchor = *anchor
+
 		case EOF:
-			break loop
 		default:
 			return Link{}, ErrInvalidLink
 		}
@@ -86,8 +213,6 @@ loop:
 
 func (p *parser) href() (url.URL, error) {
 
-	var uri *url.URL
-
 	token, _, err := p.scanIgnoreWhitespace()
 
 	if err != nil {
@@ -98,17 +223,21 @@ func (p *parser) href() (url.URL, error) {
 		return url.URL{}, ErrInvalidLink
 	}
 
-	token, literal, err := p.scanIgnoreWhitespace()
+	token, literal, err := p.scan()
 
 	if err != nil {
 		return url.URL{}, err
 	}
 
-	if token != WORD {
+	// an empty URI reference refers to the context
+	if token == GT {
+		p.unscan()
+		literal = ""
+	} else if token != WORD {
 		return url.URL{}, ErrInvalidLink
 	}
 
-	uri, err = url.Parse(literal)
+	uri, err := url.Parse(strings.TrimSpace(literal))
 
 	if err != nil {
 		return url.URL{}, err
@@ -130,7 +259,7 @@ func (p *parser) href() (url.URL, error) {
 		return url.URL{}, err
 	}
 
-	if token != SEMICOLON && token != EOF {
+	if token != SEMICOLON && !p.terminates(token) {
 		return url.URL{}, ErrMissingSemicolon
 	}
 
@@ -138,25 +267,34 @@ func (p *parser) href() (url.URL, error) {
 
 }
 
-func (p *parser) attribute() (token, string, string, bool, error) {
+// param is a parsed link-param
+type param struct {
+	token token
+	key   string
+	value string
 
-	token, key, hasStar, err := p.attributeKey()
+	// star is true for an extended parameter, e.g. title*, and valueless for a parameter without a value
+	star      bool
+	valueless bool
+}
 
-	if err != nil {
-		return INVALID, "", "", false, err
-	}
+func (p *parser) attribute() (param, error) {
 
-	if token == EOF {
-		return EOF, "", "", false, nil
+	result, err := p.attributeKey()
+
+	if err != nil || result.token == EOF || result.valueless {
+		return result, err
 	}
 
 	value, err := p.attributeValue()
 
 	if err != nil {
-		return INVALID, "", "", false, err
+		return param{token: INVALID}, err
 	}
 
-	return token, key, value, hasStar, nil
+	result.value = value
+
+	return result, nil
 
 }
 
@@ -171,129 +309,103 @@ func (p parser) isValidAttributeKey(t token) bool {
 
 }
 
-func (p *parser) attributeKey() (token, string, bool, error) {
+func (p *parser) attributeKey() (param, error) {
 
 	token, literal, err := p.scanIgnoreWhitespace()
 
 	if err != nil {
-		return INVALID, "", false, err
+		return param{token: INVALID}, err
 	}
 
-	if token == EOF {
-		return EOF, literal, false, nil
+	// a trailing semicolon
+	if p.terminates(token) {
+		return param{token: EOF, key: literal}, nil
 	}
 
 	if !p.isValidAttributeKey(token) {
-		return INVALID, "", false, ErrInvalidLink
+		return param{token: INVALID}, ErrInvalidLink
 	}
 
-	var (
-		hasStar  bool
-		keyToken = token
-		key      = literal
-	)
+	result := param{token: token, key: literal}
 
 	for {
 
 		token, _, err := p.scanIgnoreWhitespace()
 
 		if err != nil {
-			return INVALID, "", false, err
+			return param{token: INVALID}, err
 		}
 
 		if token == STAR {
-			hasStar = true
+			result.star = true
 			continue
 		}
 
+		// a parameter without a value, which only extensions may be
+		if token == SEMICOLON || p.terminates(token) {
+
+			if result.token != WORD || result.star || strings.EqualFold(result.key, "anchor") {
+				return param{token: INVALID}, ErrMissingAttrValue
+			}
+
+			result.valueless = true
+			break
+
+		}
+
 		if token != EQ {
-			return INVALID, "", false, ErrInvalidLink
+			return param{token: INVALID}, ErrInvalidLink
 		}
 
 		break
 
 	}
 
-	return keyToken, key, hasStar, nil
+	return result, nil
 
 }
 
+// attributeValue parses a token or quoted-string attribute value
 func (p *parser) attributeValue() (string, error) {
 
-	var (
-		valueRead   bool
-		quoteOpened bool
-		quoteClosed bool
-		value       string
-	)
-
-	for {
-
-		token, literal, err := p.scanIgnoreWhitespace()
-
-		if err != nil && value != "" {
-			return "", err
-		}
-
-		// optional quote
-		if token == QUOTE {
-
-			// if we've already scanned a quote
-			if quoteOpened {
-
-				// then this closes the quote
-				quoteClosed = true
-
-				// and we've read the value
-				valueRead = true
-
-			}
-
-			quoteOpened = true
-
-		} else if token == WORD { // value word
-
-			// if we've not already read the value
-			if !valueRead {
-
-				// then we have now
-				valueRead = true
-
-				// and this is it
-				value = literal
+	token, literal, err := p.scanIgnoreWhitespace()
 
-				break
+	if err != nil {
+		return "", err
+	}
 
-			}
+	var value string
 
-			// otherwise, we're missing a semicolon
-			return "", ErrMissingSemicolon
+	switch token {
+	case QUOTE:
 
-		} else { // anything else
+		token, literal, err = p.scan()
 
-			// if the value hasn't been read
-			if !valueRead {
+		if err != nil {
+			return "", err
+		}
 
-				// we're missing the attribute value
-				return "", ErrMissingAttrValue
+		if token == WORD {
 
-			}
+			value = literal
 
-			// if we're at EOF
-			if token == EOF {
-				// we're done
-				break
+			if token, _, err = p.scan(); err != nil {
+				return "", err
 			}
 
-			// otherwise, the link is invalid
-			return "", ErrInvalidLink
+		}
 
+		if token != QUOTE {
+			return "", ErrMissingClosingQuote
 		}
 
-	}
+	case WORD, REL, HREFLANG, MEDIA, TITLE, TYPE:
 
-	if err := p.verifyQuoteTerminated(quoteOpened, quoteClosed); err != nil {
-		return "", err
+		// a token that happens to match an attribute name is still a value
+		value = literal
+
+	default:
+		return "", ErrMissingAttrValue
 	}
 
 	if err := p.verifyAttributeTerminatedOrEOF(); err != nil {
@@ -304,29 +416,6 @@ func (p *parser) attributeValue() (string, error) {
 
 }
 
-func (p *parser) verifyQuoteTerminated(quoteOpened bool, quoteClosed bool) error {
-
-	// if the quote is unterminated
-	if quoteOpened && !quoteClosed {
-
-		// scan for the next non-whitespace token
-		token, _, err := p.scanIgnoreWhitespace()
-
-		// if there's an error
-		if err != nil {
-			return err
-		}
-
-		if token != QUOTE { // otherwise if the token is not a QUOTE, we're missing a closing quote
-			return ErrMissingClosingQuote
-		}
-
-	}
-
-	return nil
-
-}
-
 func (p *parser) verifyAttributeTerminatedOrEOF() error {
 
 	// scan for the next non-whitespace token
@@ -336,7 +425,7 @@ func (p *parser) verifyAttributeTerminatedOrEOF() error {
 		return err
 	}
 
-	if token != SEMICOLON && token != EOF {
+	if token != SEMICOLON && !p.terminates(token) {
 		return ErrMissingSemicolon
 	}
 
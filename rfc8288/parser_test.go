@@ -66,36 +66,36 @@ var _ = Describe("parser", func() {
 		),
 		Entry(
 			"href, rel, hreflang, title, title*",
-			`<https://www.google.com>; rel="next"; hreflang="en"; title="title"; title*="title*"`,
+			`<https://www.google.com>; rel="next"; hreflang="en"; title="title"; title*=UTF-8'en'%E2%82%AC%20rates`,
 			Link{
 				HREF:      URL("https://www.google.com"),
 				Rel:       "next",
 				HREFLang:  "en",
 				Title:     "title",
-				TitleStar: "title*",
+				TitleStar: ExtValue{Charset: "UTF-8", Language: "en", Value: "€ rates"},
 			},
 		),
 		Entry(
 			"href, rel, hreflang, title, title*, type",
-			`<https://www.google.com>; rel="next"; hreflang="en"; title="title"; title*="title*"; type="type"`,
+			`<https://www.google.com>; rel="next"; hreflang="en"; title="title"; title*=UTF-8'en'%E2%82%AC%20rates; type="type"`,
 			Link{
 				HREF:      URL("https://www.google.com"),
 				Rel:       "next",
 				HREFLang:  "en",
 				Title:     "title",
-				TitleStar: "title*",
+				TitleStar: ExtValue{Charset: "UTF-8", Language: "en", Value: "€ rates"},
 				Type:      "type",
 			},
 		),
 		Entry(
 			"href, rel, hreflang, title, title*, type, extensions",
-			`<https://www.google.com>; rel="next"; hreflang="en"; title="title"; title*="title*"; type="type"; extension="value"`,
+			`<https://www.google.com>; rel="next"; hreflang="en"; title="title"; title*=UTF-8'en'%E2%82%AC%20rates; type="type"; extension=value`,
 			Link{
 				HREF:          URL("https://www.google.com"),
 				Rel:           "next",
 				HREFLang:      "en",
 				Title:         "title",
-				TitleStar:     "title*",
+				TitleStar:     ExtValue{Charset: "UTF-8", Language: "en", Value: "€ rates"},
 				Type:          "type",
 				extensionKeys: []string{"extension"},
 				extensions: map[string]interface{}{
@@ -103,6 +103,120 @@ var _ = Describe("parser", func() {
 				},
 			},
 		),
+		Entry(
+			"quoted values containing whitespace and delimiters",
+			`<https://www.google.com>; rel="next prefetch"; title="a; b, c = \"d\""`,
+			Link{
+				HREF:  URL("https://www.google.com"),
+				Rel:   "next prefetch",
+				Title: `a; b, c = "d"`,
+			},
+		),
+		Entry(
+			"token values, including attribute names",
+			`<https://www.google.com>; rel=next; hreflang=en; title=type`,
+			Link{
+				HREF:     URL("https://www.google.com"),
+				Rel:      "next",
+				HREFLang: "en",
+				Title:    "type",
+			},
+		),
+		Entry(
+			"empty quoted value",
+			`<https://www.google.com>; title=""; type="type"`,
+			Link{
+				HREF: URL("https://www.google.com"),
+				Type: "type",
+			},
+		),
+		Entry(
+			"empty URI reference",
+			`<>; rel="self"`,
+			Link{
+				Rel: "self",
+			},
+		),
+		Entry(
+			"repeated rel",
+			`<https://www.google.com>; rel="next"; rel="prev"`,
+			Link{
+				HREF: URL("https://www.google.com"),
+				Rel:  "next",
+			},
+		),
+		Entry(
+			"anchor",
+			`<https://www.google.com>; rel="next"; Anchor="#section"`,
+			Link{
+				HREF:   URL("https://www.google.com"),
+				Anchor: URL("#section"),
+				Rel:    "next",
+			},
+		),
+		Entry(
+			"quoted title*",
+			`<https://www.google.com>; title*="iso-8859-1'de'%A3%20rates"`,
+			Link{
+				HREF:      URL("https://www.google.com"),
+				TitleStar: ExtValue{Charset: "iso-8859-1", Language: "de", Value: "£ rates"},
+			},
+		),
+		Entry(
+			"extended extension",
+			`<https://www.google.com>; description*=UTF-8''caf%C3%A9`,
+			Link{
+				HREF:          URL("https://www.google.com"),
+				extensionKeys: []string{"description*"},
+				extensions: map[string]interface{}{
+					"description*": ExtValue{Charset: "UTF-8", Value: "café"},
+				},
+			},
+		),
+		Entry(
+			"extensions without a value",
+			`<https://www.google.com>; crossorigin; rel="next"; hidden`,
+			Link{
+				HREF:          URL("https://www.google.com"),
+				Rel:           "next",
+				extensionKeys: []string{"crossorigin", "hidden"},
+				extensions: map[string]interface{}{
+					"crossorigin": true,
+					"hidden":      true,
+				},
+			},
+		),
+		Entry(
+			"trailing semicolon",
+			`<https://www.google.com>; rel="next";`,
+			Link{
+				HREF: URL("https://www.google.com"),
+				Rel:  "next",
+			},
+		),
+	)
+
+	DescribeTable(
+		"parse() error cases",
+		func(in string, out error) {
+
+			// when
+			_, err := ParseLink(in)
+
+			// expect
+			Expect(err).To(Equal(out))
+
+		},
+		Entry("missing URI reference", `rel="next"`, ErrInvalidLink),
+		Entry("missing semicolon", `<https://www.google.com> rel="next"`, ErrMissingSemicolon),
+		Entry("missing attribute value", `<https://www.google.com>; rel=;`, ErrMissingAttrValue),
+		Entry("missing closing quote", `<https://www.google.com>; rel="next`, ErrMissingClosingQuote),
+		Entry("invalid title*", `<https://www.google.com>; title*=title`, ErrInvalidExtValue),
+		Entry("invalid extended extension", `<https://www.google.com>; description*=UTF-8''%E2%82`, ErrInvalidExtValue),
+		Entry("rel without a value", `<https://www.google.com>; rel; title="title"`, ErrMissingAttrValue),
+		Entry("anchor without a value", `<https://www.google.com>; anchor`, ErrMissingAttrValue),
+		Entry("title* without a value", `<https://www.google.com>; title*`, ErrMissingAttrValue),
+		Entry("several links", `<https://www.google.com>; rel="next", <https://www.google.com>`, ErrMissingSemicolon),
 	)
 
 })
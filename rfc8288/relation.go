@@ -0,0 +1,256 @@
+package rfc8288
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// RelationRegistryURI is the location of the IANA Link Relation Types registry established by RFC8288 Sec. 6.2.2
+	RelationRegistryURI = "https://www.iana.org/assignments/link-relations"
+)
+
+// registered relation types
+const (
+	RelAbout              = "about"
+	RelAlternate          = "alternate"
+	RelAPICatalog         = "api-catalog"
+	RelAuthor             = "author"
+	RelBookmark           = "bookmark"
+	RelCanonical          = "canonical"
+	RelCollection         = "collection"
+	RelDescribedBy        = "describedby"
+	RelDescribes          = "describes"
+	RelEdit               = "edit"
+	RelEditMedia          = "edit-media"
+	RelEnclosure          = "enclosure"
+	RelFirst              = "first"
+	RelHelp               = "help"
+	RelHub                = "hub"
+	RelIcon               = "icon"
+	RelItem               = "item"
+	RelLast               = "last"
+	RelLatestVersion      = "latest-version"
+	RelLicense            = "license"
+	RelLinkset            = "linkset"
+	RelNext               = "next"
+	RelNextArchive        = "next-archive"
+	RelNoFollow           = "nofollow"
+	RelNoOpener           = "noopener"
+	RelNoReferrer         = "noreferrer"
+	RelPredecessorVersion = "predecessor-version"
+	RelPrefetch           = "prefetch"
+	RelPreload            = "preload"
+	RelPrev               = "prev"
+	RelPrevArchive        = "prev-archive"
+	RelPrivacyPolicy      = "privacy-policy"
+	RelProfile            = "profile"
+	RelRelated            = "related"
+	RelReplies            = "replies"
+	RelSearch             = "search"
+	RelSelf               = "self"
+	RelService            = "service"
+	RelServiceDesc        = "service-desc"
+	RelServiceDoc         = "service-doc"
+	RelServiceMeta        = "service-meta"
+	RelStatus             = "status"
+	RelStylesheet         = "stylesheet"
+	RelSuccessorVersion   = "successor-version"
+	RelTermsOfService     = "terms-of-service"
+	RelType               = "type"
+	RelUp                 = "up"
+	RelVersionHistory     = "version-history"
+	RelVia                = "via"
+	RelWorkingCopy        = "working-copy"
+	RelWorkingCopyOf      = "working-copy-of"
+)
+
+var (
+	// ErrInvalidRelationType describes a relation type that is neither a registered relation type name nor a URI
+	ErrInvalidRelationType = errors.New("rfc8288: invalid relation type, extension relation types must be URIs")
+
+	// ErrUnregisteredRelationType describes a relation type name that has not been registered
+	ErrUnregisteredRelationType = errors.New("rfc8288: relation type is not registered")
+
+	// ErrDuplicateRelationType describes an attempt to register a relation type that is already registered
+	ErrDuplicateRelationType = errors.New("rfc8288: relation type is already registered")
+)
+
+// regRelType matches a registered relation type name as defined by RFC8288 Sec. 3.3
+var regRelType = regexp.MustCompile(`^[a-z][a-z0-9.\-]*$`)
+
+// RelationType is an entry of the Link Relation Types registry as described by RFC8288 Sec. 2.1.1
+type RelationType struct {
+	Name        string
+	Description string
+	Reference   string
+}
+
+// relations holds the registered relation types by name
+var relations = struct {
+	mu    sync.RWMutex
+	types map[string]RelationType
+}{
+	types: map[string]RelationType{},
+}
+
+func init() {
+
+	for _, t := range []RelationType{
+		{RelAbout, "Refers to a resource that is the subject of the link's context.", "RFC 6903 Sec. 2"},
+		{RelAlternate, "Refers to a substitute for this context.", "HTML"},
+		{RelAPICatalog, "Refers to a list of APIs available from the publisher of the link's context.", "RFC 9727"},
+		{RelAuthor, "Refers to the context's author.", "HTML"},
+		{RelBookmark, "Gives a permanent link to use for bookmarking purposes.", "HTML"},
+		{RelCanonical, "Designates the preferred version of a resource.", "RFC 6596"},
+		{RelCollection, "Refers to a resource representing the collection of which the context is a member.", "RFC 6573"},
+		{RelDescribedBy, "Refers to a resource providing information about the link's context.", "W3C POWDER"},
+		{RelDescribes, "Refers to a resource described by the link's context.", "RFC 6892"},
+		{RelEdit, "Refers to a resource that can be used to edit the link's context.", "RFC 5023"},
+		{RelEditMedia, "Refers to a resource that can be used to edit media associated with the link's context.", "RFC 5023"},
+		{RelEnclosure, "Identifies a related resource that is potentially large and might require special handling.", "RFC 4287"},
+		{RelFirst, "Refers to the furthest preceding resource in a series of resources.", "RFC 8288"},
+		{RelHelp, "Refers to context-sensitive help.", "HTML"},
+		{RelHub, "Refers to a hub that enables registration for notification of updates to the context.", "WebSub"},
+		{RelIcon, "Refers to an icon representing the link's context.", "HTML"},
+		{RelItem, "Refers to a resource that is a member of the collection represented by the context.", "RFC 6573"},
+		{RelLast, "Refers to the furthest following resource in a series of resources.", "RFC 8288"},
+		{RelLatestVersion, "Refers to the latest version of the context.", "RFC 5829"},
+		{RelLicense, "Refers to a license associated with the link's context.", "RFC 4946"},
+		{RelLinkset, "Refers to a set of links, including links in which the link's context participates.", "RFC 9264"},
+		{RelNext, "Refers to the next resource in the series of which the context is a part.", "HTML"},
+		{RelNextArchive, "Refers to the immediately following archive resource.", "RFC 5005"},
+		{RelNoFollow, "Indicates that the context's author or publisher does not endorse the link target.", "HTML"},
+		{RelNoOpener, "Indicates that any newly created browsing context must not have an opener.", "HTML"},
+		{RelNoReferrer, "Indicates that no referrer information is to be leaked when following the link.", "HTML"},
+		{RelPredecessorVersion, "Refers to the predecessor version in the version history.", "RFC 5829"},
+		{RelPrefetch, "Indicates that the link target should be preemptively cached.", "HTML"},
+		{RelPreload, "Refers to a resource that should be loaded early in the processing of the link's context.", "W3C Preload"},
+		{RelPrev, "Refers to the previous resource in the series of which the context is a part.", "HTML"},
+		{RelPrevArchive, "Refers to the immediately preceding archive resource.", "RFC 5005"},
+		{RelPrivacyPolicy, "Refers to a privacy policy associated with the link's context.", "RFC 6903 Sec. 3"},
+		{RelProfile, "Identifies a profile to which the context's representation conforms.", "RFC 6906"},
+		{RelRelated, "Identifies a related resource.", "RFC 4287"},
+		{RelReplies, "Identifies a resource that is a reply to the context of the link.", "RFC 4685"},
+		{RelSearch, "Refers to a resource that can be used to search through the link's context and related resources.", "OpenSearch"},
+		{RelSelf, "Conveys an identifier for the link's context.", "RFC 4287"},
+		{RelService, "Refers to a service document.", "RFC 5023"},
+		{RelServiceDesc, "Identifies a service description for the context, primarily intended for machines.", "RFC 8631"},
+		{RelServiceDoc, "Identifies service documentation for the context, primarily intended for humans.", "RFC 8631"},
+		{RelServiceMeta, "Identifies general metadata for the context, primarily intended for machines.", "RFC 8631"},
+		{RelStatus, "Identifies a resource that represents the context's status.", "RFC 8631"},
+		{RelStylesheet, "Refers to a stylesheet.", "HTML"},
+		{RelSuccessorVersion, "Refers to the successor version in the version history.", "RFC 5829"},
+		{RelTermsOfService, "Refers to the terms of service associated with the link's context.", "RFC 6903 Sec. 4"},
+		{RelType, "Refers to a resource identifying the abstract semantic type of which the context is an instance.", "RFC 6903 Sec. 6"},
+		{RelUp, "Refers to a parent document in a hierarchy of documents.", "RFC 8288"},
+		{RelVersionHistory, "Refers to the version history of the context.", "RFC 5829"},
+		{RelVia, "Identifies a resource that is the source of the information in the link's context.", "RFC 4287"},
+		{RelWorkingCopy, "Refers to a working copy of the context.", "RFC 5829"},
+		{RelWorkingCopyOf, "Refers to the versioned resource from which the context was checked out.", "RFC 5829"},
+	} {
+		relations.types[t.Name] = t
+	}
+
+}
+
+// RegisterRelation registers a relation type for use by the application. The name must be a registered relation type
+// name, i.e. lowercase letters, digits, "." and "-" beginning with a letter, that is not already registered.
+func RegisterRelation(t RelationType) error {
+
+	if !regRelType.MatchString(t.Name) {
+		return ErrInvalidRelationType
+	}
+
+	relations.mu.Lock()
+	defer relations.mu.Unlock()
+
+	if _, ok := relations.types[t.Name]; ok {
+		return ErrDuplicateRelationType
+	}
+
+	relations.types[t.Name] = t
+
+	return nil
+
+}
+
+// LookupRelation returns the registration of the given relation type, compared case-insensitively, if registered
+func LookupRelation(rel string) (RelationType, bool) {
+
+	relations.mu.RLock()
+	defer relations.mu.RUnlock()
+
+	t, ok := relations.types[strings.ToLower(rel)]
+	return t, ok
+
+}
+
+// RegisteredRelations returns every registered relation type, ordered by name
+func RegisteredRelations() []RelationType {
+
+	relations.mu.RLock()
+	defer relations.mu.RUnlock()
+
+	var result []RelationType
+
+	for _, t := range relations.types {
+		result = append(result, t)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+
+}
+
+// IsRegisteredRelation returns whether rel is a registered relation type, compared case-insensitively
+func IsRegisteredRelation(rel string) bool {
+	_, ok := LookupRelation(rel)
+	return ok
+}
+
+// IsExtensionRelation returns whether rel is an extension relation type, i.e. an absolute URI, as described by RFC8288
+// Sec. 2.1.2
+func IsExtensionRelation(rel string) bool {
+
+	uri, err := url.Parse(rel)
+	return err == nil && uri.IsAbs()
+
+}
+
+// ValidateRelation returns nil if rel is a registered or extension relation type. ErrUnregisteredRelationType is
+// returned for a name that has not been registered, and ErrInvalidRelationType for anything else.
+func ValidateRelation(rel string) error {
+
+	switch {
+	case IsRegisteredRelation(rel), IsExtensionRelation(rel):
+		return nil
+	case regRelType.MatchString(strings.ToLower(rel)):
+		return ErrUnregisteredRelationType
+	default:
+		return ErrInvalidRelationType
+	}
+
+}
+
+// ValidateRels validates every relation type of the Link. See ValidateRelation
+func (l Link) ValidateRels() error {
+
+	for _, rel := range l.Rels() {
+
+		if err := ValidateRelation(rel); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+
+}
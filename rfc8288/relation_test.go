@@ -0,0 +1,129 @@
+package rfc8288
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Relation types", func() {
+
+	It("should register the IANA relation types", func() {
+
+		// when
+		t, ok := LookupRelation(RelServiceDesc)
+
+		// then
+		Expect(ok).To(BeTrue())
+		Expect(t.Reference).To(Equal("RFC 8631"))
+		Expect(RegisteredRelations()).To(ContainElement(t))
+
+	})
+
+	It("LookupRelation(rel) should compare relation types case-insensitively", func() {
+
+		// when
+		t, ok := LookupRelation("DescribedBy")
+
+		// then
+		Expect(ok).To(BeTrue())
+		Expect(t.Name).To(Equal(RelDescribedBy))
+
+	})
+
+	It("RegisteredRelations() should order relation types by name", func() {
+
+		// when
+		result := RegisteredRelations()
+
+		// then
+		Expect(result[0].Name).To(Equal(RelAbout))
+
+		for x := 1; x < len(result); x++ {
+			Expect(result[x-1].Name < result[x].Name).To(BeTrue())
+		}
+
+	})
+
+	It("RegisterRelation(t) should register an application relation type", func() {
+
+		// given
+		t := RelationType{Name: "x-test-register", Description: "A test relation type."}
+
+		// when
+		err := RegisterRelation(t)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(IsRegisteredRelation("x-test-register")).To(BeTrue())
+		Expect(ValidateRelation("x-test-register")).To(Succeed())
+		Expect(RegisterRelation(t)).To(Equal(ErrDuplicateRelationType))
+
+	})
+
+	DescribeTable(
+		"RegisterRelation(t) should return an error",
+		func(name string, expected error) {
+
+			// when
+			err := RegisterRelation(RelationType{Name: name})
+
+			// then
+			Expect(err).To(Equal(expected))
+
+		},
+		Entry("already registered", RelNext, ErrDuplicateRelationType),
+		Entry("empty name", "", ErrInvalidRelationType),
+		Entry("uppercase name", "Next-Page", ErrInvalidRelationType),
+		Entry("URI", "https://example.com/rel", ErrInvalidRelationType),
+		Entry("leading digit", "1st", ErrInvalidRelationType),
+	)
+
+	DescribeTable(
+		"ValidateRelation(rel)",
+		func(rel string, expected error) {
+
+			// when
+			err := ValidateRelation(rel)
+
+			// then
+			if expected == nil {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(Equal(expected))
+			}
+
+		},
+		Entry("registered", RelNext, nil),
+		Entry("registered, mixed case", "Next", nil),
+		Entry("extension relation type", "https://example.com/rels/widget", nil),
+		Entry("extension relation type URN", "urn:example:widget", nil),
+		Entry("unregistered", "widget", ErrUnregisteredRelationType),
+		Entry("relative reference", "/rels/widget", ErrInvalidRelationType),
+		Entry("empty", "", ErrInvalidRelationType),
+	)
+
+	DescribeTable(
+		"Link.ValidateRels()",
+		func(rel string, expected error) {
+
+			// given
+			l := Link{HREF: URL("https://example.com"), Rel: rel}
+
+			// when
+			err := l.ValidateRels()
+
+			// then
+			if expected == nil {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(Equal(expected))
+			}
+
+		},
+		Entry("no relation types", "", nil),
+		Entry("registered and extension relation types", "next https://example.com/rels/widget", nil),
+		Entry("an unregistered relation type", "next widget", ErrUnregisteredRelationType),
+	)
+
+})
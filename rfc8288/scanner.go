@@ -19,6 +19,7 @@ const (
 	LT
 	GT
 	EQ
+	COMMA
 
 	// special
 	EOF
@@ -43,7 +44,7 @@ func isWhitespace(r rune) bool {
 
 // isSymbol returns true if rune is a link symbol
 func isSymbol(r rune) bool {
-	return r == '"' || r == ';' || r == '<' || r == '>' || r == '='
+	return r == '"' || r == ';' || r == '<' || r == '>' || r == '=' || r == ','
 }
 
 // isStar returns true if rune is an asterisk
@@ -63,6 +64,15 @@ type scanner struct {
 // scan returns the next token and literal, or error
 func (s *scanner) Scan() (token, string, error) {
 
+	// the content of quoted strings and URI references is scanned whole, as it may contain symbols
+	if s.quoteOpen && s.lastRead == QUOTE {
+		return s.scanUntil('"', true)
+	}
+
+	if s.bracketOpen && s.lastRead == LT {
+		return s.scanUntil('>', false)
+	}
+
 	// read
 	if r, err := s.read(); err != nil { // eof
 
@@ -100,6 +110,8 @@ func (s *scanner) Scan() (token, string, error) {
 			return s.scanned(GT, string(r), nil)
 		case '=':
 			return s.scanned(EQ, string(r), nil)
+		case ',':
+			return s.scanned(COMMA, string(r), nil)
 		}
 
 	}
@@ -245,6 +257,59 @@ func (s *scanner) scanWord() (token, string, error) {
 
 }
 
+// scanUntil scans the runes preceding the delimiter as a WORD, unless there are none. When escapes is true, a
+// backslash escapes the rune that follows it, as within a quoted-string.
+func (s *scanner) scanUntil(delimiter rune, escapes bool) (token, string, error) {
+
+	// buf is a place to store the runes preceding the delimiter
+	var buf bytes.Buffer
+
+	for {
+
+		r, err := s.read()
+
+		if err != nil {
+
+			// eof, the delimiter is missing
+			break
+
+		}
+
+		if r == delimiter {
+
+			if err := s.unread(); err != nil {
+				return INVALID, "", err
+			}
+
+			break
+
+		}
+
+		if r == '\\' && escapes {
+
+			// write the escaped rune, or nothing at eof
+			if r, err = s.read(); err != nil {
+				break
+			}
+
+		}
+
+		buf.WriteRune(r)
+
+	}
+
+	if buf.Len() == 0 {
+
+		// nothing precedes the delimiter, so scan it as usual
+		s.lastRead = INVALID
+		return s.Scan()
+
+	}
+
+	return s.scanned(WORD, buf.String(), nil)
+
+}
+
 // scanned tells the scanner what we've just scanned. the error parameter is passthrough as a convenience
 func (s *scanner) scanned(t token, literal string, err error) (token, string, error) {
 	s.lastRead = t
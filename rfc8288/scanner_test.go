@@ -98,6 +98,27 @@ var _ = Describe("scanner", func() {
 				{Token: EOF, Literal: ""},
 			},
 		),
+		Entry(
+			"delimiters within quoted strings and URI references",
+			`<https://example.com/a,b;c>; title="a, \"b\"", <>`,
+			[]TokenLiteral{
+				{Token: LT, Literal: "<"},
+				{Token: WORD, Literal: "https://example.com/a,b;c"},
+				{Token: GT, Literal: ">"},
+				{Token: SEMICOLON, Literal: ";"},
+				{Token: WS, Literal: " "},
+				{Token: TITLE, Literal: "title"},
+				{Token: EQ, Literal: "="},
+				{Token: QUOTE, Literal: `"`},
+				{Token: WORD, Literal: `a, "b"`},
+				{Token: QUOTE, Literal: `"`},
+				{Token: COMMA, Literal: ","},
+				{Token: WS, Literal: " "},
+				{Token: LT, Literal: "<"},
+				{Token: GT, Literal: ">"},
+				{Token: EOF, Literal: ""},
+			},
+		),
 		Entry(
 			"Edge Case: Ends with whitespace",
 			" ",
@@ -0,0 +1,3 @@
+// Package rfc9457 contains an implementation of IETF's RFC 9457, which obsoletes RFC 7807 while retaining its Problem
+// Details format. See: https://www.rfc-editor.org/rfc/rfc9457
+package rfc9457
@@ -0,0 +1,132 @@
+package rfc9457
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/tniswong/go.rfcx/rfc7807"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// JSONMediaType is the MIME Media type for the Problem struct, unchanged from RFC 7807
+	JSONMediaType = rfc7807.JSONMediaType
+
+	// XMLMediaType is the MIME Media type for the XML representation of the Problem struct, unchanged from RFC 7807
+	XMLMediaType = rfc7807.XMLMediaType
+
+	// Blank is the registered problem type used when a problem has no semantics beyond those of the HTTP status code
+	Blank = "about:blank"
+)
+
+// Compatibility Errors
+var (
+	ErrRelativeReference = errors.New("rfc9457: a relative type or instance reference cannot be resolved without a base URI")
+)
+
+// Problem is a struct representing Problem Details as described in RFC 9457. The format is unchanged from RFC 7807.
+type Problem = rfc7807.Problem
+
+// Resolve returns a copy of the Problem with its type and instance resolved against base, which is usually the URI of
+// the request. According to RFC 9457 Sec. 3.1.1:
+//
+//	This member's value is a URI reference. Consumers MUST use the "type"
+//	URI (after resolution, if necessary) as the problem type's primary
+//	identifier.
+//
+// A type that is not a valid URI reference is left unchanged, as is the Problem when base is nil.
+func Resolve(p Problem, base *url.URL) Problem {
+
+	if base == nil {
+		return p
+	}
+
+	if p.Type != "" {
+
+		if ref, err := url.Parse(p.Type); err == nil {
+			p.Type = base.ResolveReference(ref).String()
+		}
+
+	}
+
+	var zero url.URL
+	if p.Instance != zero {
+		p.Instance = *base.ResolveReference(&p.Instance)
+	}
+
+	return p
+
+}
+
+// Normalize returns a copy of the Problem with the "about:blank" semantics of RFC 9457 Sec. 4.2.1 applied: an absent
+// type is made explicit as Blank, and a Blank problem without a title is given the HTTP status phrase of its status.
+func Normalize(p Problem) Problem {
+
+	if p.Type == "" {
+		p.Type = Blank
+	}
+
+	if p.Type == Blank && p.Title == "" {
+		p.Title = http.StatusText(p.Status)
+	}
+
+	return p
+
+}
+
+// IsBlank returns whether the Problem's type is "about:blank", explicitly or by omission
+func IsBlank(p Problem) bool {
+	return p.Type == "" || p.Type == Blank
+}
+
+// Compatibility selects whether an Encoder may emit relative type and instance references
+type Compatibility int
+
+// Compatibility modes
+const (
+	// RFC9457 emits type and instance as given, relative references included
+	RFC9457 Compatibility = iota
+
+	// RFC7807 emits absolute type and instance URIs only, resolving them against the Encoder's Base. Both RFCs require
+	// consumers to resolve relative references, so this is a compatibility choice for consumers that do not.
+	RFC7807
+)
+
+// Encoder marshals Problems according to its Compatibility
+type Encoder struct {
+	Compatibility Compatibility
+
+	// Base is the URI relative references are resolved against in RFC7807 mode
+	Base *url.URL
+}
+
+// Marshal returns the JSON encoding of the Problem
+func (e Encoder) Marshal(p Problem) ([]byte, error) {
+
+	if e.Compatibility == RFC7807 {
+
+		if p = Resolve(p, e.Base); !absolute(p) {
+			return nil, ErrRelativeReference
+		}
+
+	}
+
+	return json.Marshal(p)
+
+}
+
+// absolute returns whether the Problem's type and instance are absent or absolute URIs
+func absolute(p Problem) bool {
+
+	if p.Type != "" {
+
+		if uri, err := url.Parse(p.Type); err != nil || !uri.IsAbs() {
+			return false
+		}
+
+	}
+
+	var zero url.URL
+	return p.Instance == zero || p.Instance.IsAbs()
+
+}
@@ -0,0 +1,135 @@
+package rfc9457
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"net/http"
+)
+
+var _ = Describe("Rfc9457", func() {
+
+	DescribeTable(
+		"Resolve(p, base)",
+		func(in Problem, problemType string, instance string) {
+
+			// when
+			result := Resolve(in, URL("https://example.com/api/accounts/12345"))
+
+			// then
+			Expect(result.Type).To(Equal(problemType))
+			Expect(result.Instance.String()).To(Equal(instance))
+
+		},
+		Entry(
+			"absolute references are unchanged",
+			Problem{Type: "https://example.net/probs/out-of-credit", Instance: *URL("https://example.net/msgs/abc")},
+			"https://example.net/probs/out-of-credit",
+			"https://example.net/msgs/abc",
+		),
+		Entry(
+			"relative references are resolved",
+			Problem{Type: "/probs/out-of-credit", Instance: *URL("msgs/abc")},
+			"https://example.com/probs/out-of-credit",
+			"https://example.com/api/accounts/msgs/abc",
+		),
+		Entry(
+			"about:blank is unchanged",
+			Problem{Type: Blank},
+			Blank,
+			"",
+		),
+		Entry(
+			"absent members remain absent",
+			Problem{},
+			"",
+			"",
+		),
+	)
+
+	It("Resolve(p, nil) should return the Problem unchanged", func() {
+
+		// given
+		p := Problem{Type: "/probs/out-of-credit", Instance: *URL("msgs/abc")}
+
+		// when
+		result := Resolve(p, nil)
+
+		// then
+		Expect(result).To(Equal(p))
+
+	})
+
+	DescribeTable(
+		"Normalize(p)",
+		func(in Problem, out Problem) {
+			Expect(Normalize(in)).To(Equal(out))
+		},
+		Entry(
+			"absent type is about:blank with the status phrase as title",
+			Problem{Status: http.StatusNotFound},
+			Problem{Type: Blank, Title: "Not Found", Status: http.StatusNotFound},
+		),
+		Entry(
+			"an existing title is kept",
+			Problem{Type: Blank, Title: "Nicht gefunden", Status: http.StatusNotFound},
+			Problem{Type: Blank, Title: "Nicht gefunden", Status: http.StatusNotFound},
+		),
+		Entry(
+			"other types are unchanged",
+			Problem{Type: "https://example.net/probs/out-of-credit", Status: http.StatusForbidden},
+			Problem{Type: "https://example.net/probs/out-of-credit", Status: http.StatusForbidden},
+		),
+	)
+
+	It("IsBlank(p) should treat an absent type as about:blank", func() {
+		Expect(IsBlank(Problem{})).To(BeTrue())
+		Expect(IsBlank(Problem{Type: Blank})).To(BeTrue())
+		Expect(IsBlank(Problem{Type: "https://example.net/probs/out-of-credit"})).To(BeFalse())
+	})
+
+	Describe("Encoder", func() {
+
+		relative := Problem{Type: "/probs/out-of-credit", Instance: *URL("/msgs/abc")}
+
+		It("should emit relative references in RFC9457 mode", func() {
+
+			// when
+			data, err := Encoder{}.Marshal(relative)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(data).To(MatchJSON(`{"type": "/probs/out-of-credit", "instance": "/msgs/abc"}`))
+
+		})
+
+		It("should emit absolute URIs in RFC7807 mode", func() {
+
+			// given
+			e := Encoder{Compatibility: RFC7807, Base: URL("https://example.com/accounts/12345")}
+
+			// when
+			data, err := e.Marshal(relative)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(data).To(MatchJSON(`{
+                "type": "https://example.com/probs/out-of-credit",
+                "instance": "https://example.com/msgs/abc"
+            }`))
+
+		})
+
+		It("should return ErrRelativeReference in RFC7807 mode without a Base", func() {
+
+			// when
+			_, err := Encoder{Compatibility: RFC7807}.Marshal(relative)
+
+			// then
+			Expect(err).To(Equal(ErrRelativeReference))
+
+		})
+
+	})
+
+})
@@ -0,0 +1,87 @@
+package rfc9457
+
+import (
+	"github.com/tniswong/go.rfcx/rfc7807"
+	"sort"
+)
+
+const (
+	// RegistryURI is the location of the IANA HTTP Problem Types registry established by RFC 9457 Sec. 4.2
+	RegistryURI = "https://www.iana.org/assignments/http-problem-types"
+)
+
+// RegisteredType is an entry of the IANA HTTP Problem Types registry as described by RFC 9457 Sec. 4.2
+type RegisteredType struct {
+	Type      string
+	Title     string
+	Status    int // the recommended HTTP status code, or 0 if any may be used
+	Reference string
+}
+
+// Problem returns a Problem of the registered type, with the given status when the registration does not recommend
+// one
+func (t RegisteredType) Problem(status int) Problem {
+
+	if t.Status != 0 {
+		status = t.Status
+	}
+
+	return Normalize(Problem{
+		Type:   t.Type,
+		Title:  t.Title,
+		Status: status,
+	})
+
+}
+
+// ProblemType returns the registered type as an rfc7807.ProblemType with the given schema name, to be registered with
+// rfc7807.Schemas and an rfc7807.Catalog alongside the application's own problem types
+func (t RegisteredType) ProblemType(name string) rfc7807.ProblemType {
+
+	return rfc7807.ProblemType{
+		Name:        name,
+		Type:        t.Type,
+		Title:       t.Title,
+		Status:      t.Status,
+		Description: "See " + t.Reference + ".",
+	}
+
+}
+
+// registry holds the registered problem types by type URI
+var registry = map[string]RegisteredType{
+	Blank: {
+		Type:      Blank,
+		Title:     "", // the HTTP status phrase of the problem's status, see Normalize
+		Reference: "RFC 9457 Sec. 4.2.1",
+	},
+}
+
+// Lookup returns the registration of the given problem type URI, if registered
+func Lookup(problemType string) (RegisteredType, bool) {
+
+	if problemType == "" {
+		problemType = Blank
+	}
+
+	t, ok := registry[problemType]
+	return t, ok
+
+}
+
+// RegisteredTypes returns every registered problem type, ordered by type URI
+func RegisteredTypes() []RegisteredType {
+
+	var result []RegisteredType
+
+	for _, t := range registry {
+		result = append(result, t)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Type < result[j].Type
+	})
+
+	return result
+
+}
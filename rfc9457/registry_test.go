@@ -0,0 +1,74 @@
+package rfc9457
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tniswong/go.rfcx/rfc7807"
+	"net/http"
+)
+
+var _ = Describe("Registry", func() {
+
+	It("should register about:blank", func() {
+
+		// when
+		t, ok := Lookup(Blank)
+
+		// then
+		Expect(ok).To(BeTrue())
+		Expect(t.Reference).To(Equal("RFC 9457 Sec. 4.2.1"))
+		Expect(RegisteredTypes()).To(ContainElement(t))
+
+	})
+
+	It("should look up an absent type as about:blank", func() {
+
+		// when
+		t, ok := Lookup("")
+
+		// then
+		Expect(ok).To(BeTrue())
+		Expect(t.Type).To(Equal(Blank))
+
+	})
+
+	It("should not find unregistered types", func() {
+
+		// when
+		_, ok := Lookup("https://example.net/probs/out-of-credit")
+
+		// then
+		Expect(ok).To(BeFalse())
+
+	})
+
+	It("should describe a registered type as an rfc7807.ProblemType", func() {
+
+		// given
+		t, _ := Lookup(Blank)
+
+		// when
+		result := t.ProblemType("Blank")
+
+		// then
+		Expect(result).To(Equal(rfc7807.ProblemType{Name: "Blank", Type: Blank, Description: "See RFC 9457 Sec. 4.2.1."}))
+
+		var s rfc7807.Schemas
+		Expect(s.Register(result)).To(Succeed())
+
+	})
+
+	It("should build a Problem of a registered type", func() {
+
+		// given
+		t, _ := Lookup(Blank)
+
+		// when
+		p := t.Problem(http.StatusServiceUnavailable)
+
+		// then
+		Expect(p).To(Equal(Problem{Type: Blank, Title: "Service Unavailable", Status: http.StatusServiceUnavailable}))
+
+	})
+
+})
@@ -0,0 +1,26 @@
+package rfc9457
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/url"
+	"testing"
+)
+
+func URL(u string) *url.URL {
+
+	defer GinkgoRecover()
+	uri, err := url.Parse(u)
+
+	if err != nil {
+		Fail(err.Error())
+	}
+
+	return uri
+
+}
+
+func TestRfc9457(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Rfc9457 Suite")
+}